@@ -36,6 +36,10 @@ type (
 	// It returns a boolean that indicates whether the gotten time.Duration value
 	// passes the current check.
 	DurationPassFunc func(got time.Duration) bool
+	// DurationStatsPassFunc is the required method to implement DurationStatsPasser.
+	// It returns a boolean that indicates whether the gotten []time.Duration value
+	// passes the current check.
+	DurationStatsPassFunc func(got []time.Duration) bool
 	// ContextPassFunc is the required method to implement ContextPasser.
 	// It returns a boolean that indicates whether the gotten context.Context value
 	// passes the current check.
@@ -82,6 +86,9 @@ type (
 	// DurationPasser provides a method Pass that returns a bool that indicates
 	// whether the gotten time.Duration value passes the current check.
 	DurationPasser interface{ Pass(got time.Duration) bool }
+	// DurationStatsPasser provides a method Pass that returns a bool that indicates
+	// whether the gotten []time.Duration value passes the current check.
+	DurationStatsPasser interface{ Pass(got []time.Duration) bool }
 	// ContextPasser provides a method Pass that returns a bool that indicates
 	// whether the gotten context.Context value passes the current check.
 	ContextPasser interface {
@@ -137,6 +144,11 @@ type (
 		DurationPasser
 		Explainer
 	}
+	// DurationStatsChecker implements both DurationStatsPasser and Explainer interfaces.
+	DurationStatsChecker interface {
+		DurationStatsPasser
+		Explainer
+	}
 	// ContextChecker implements both ContextPasser and Explainer interfaces.
 	ContextChecker interface {
 		ContextPasser
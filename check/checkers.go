@@ -120,6 +120,22 @@ func NewDurationChecker(passFunc DurationPassFunc, explainFunc ExplainFunc) Dura
 	return durationChecker{baseChecker: newBaseChecker(explainFunc), passFunc: passFunc}
 }
 
+// durationStatsChecker is an implementation of DurationStatsChecker interface
+type durationStatsChecker struct {
+	baseChecker
+	passFunc DurationStatsPassFunc
+}
+
+// Pass returns a boolean that indicates whether the gotten []time.Duration value
+// passes the current check.
+func (c durationStatsChecker) Pass(got []time.Duration) bool { return c.passFunc(got) }
+
+// NewDurationStatsChecker returns a custom DurationStatsChecker with the provided
+// DurationStatsPassFunc and ExplainFunc.
+func NewDurationStatsChecker(passFunc DurationStatsPassFunc, explainFunc ExplainFunc) DurationStatsChecker {
+	return durationStatsChecker{baseChecker: newBaseChecker(explainFunc), passFunc: passFunc}
+}
+
 // contextChecker is an implementation of ContextChecker interface
 type contextChecker struct {
 	baseChecker
@@ -67,6 +67,34 @@ type (
 		Under(tar time.Duration) DurationChecker
 	}
 
+	// DurationStatsCheckerProvider provides checks on type []time.Duration.
+	// The gotten slice is read as a set of duration samples, such as the ones
+	// collected by a repeated measure.
+	DurationStatsCheckerProvider interface {
+		// Max checks the maximum of the gotten samples passes the input
+		// DurationChecker.
+		Max(c DurationChecker) DurationStatsChecker
+		// Mean checks the mean of the gotten samples passes the input
+		// DurationChecker.
+		Mean(c DurationChecker) DurationStatsChecker
+		// Median checks the median of the gotten samples passes the input
+		// DurationChecker.
+		Median(c DurationChecker) DurationStatsChecker
+		// P95 checks the 95th percentile of the gotten samples passes the input
+		// DurationChecker.
+		P95(c DurationChecker) DurationStatsChecker
+		// P99 checks the 99th percentile of the gotten samples passes the input
+		// DurationChecker.
+		P99(c DurationChecker) DurationStatsChecker
+		// Percentile checks the percentile pct of the gotten samples passes
+		// the input DurationChecker, using the nearest-rank method.
+		// It panics if pct is not in range [0:100].
+		Percentile(pct float64, c DurationChecker) DurationStatsChecker
+		// StdDev checks the standard deviation of the gotten samples passes
+		// the input DurationChecker.
+		StdDev(c DurationChecker) DurationStatsChecker
+	}
+
 	// Float64CheckerProvider provides checks on type float64.
 	Float64CheckerProvider interface {
 		// GT checks the gotten float64 is greater than the target.
@@ -123,6 +151,16 @@ type (
 		// Header checks the gotten *http.Request Header passes
 		// the input HTTPHeaderChecker.
 		Header(c HTTPHeaderChecker) HTTPRequestChecker
+		// Method checks the gotten *http.Request Method passes
+		// the input StringChecker.
+		Method(c StringChecker) HTTPRequestChecker
+		// Path checks the gotten *http.Request URL path passes
+		// the input StringChecker.
+		Path(c StringChecker) HTTPRequestChecker
+		// Query checks the gotten *http.Request URL query value for the given key
+		// passes the input StringChecker. It only checks the first value
+		// for the given key, an empty string if there are none.
+		Query(key string, c StringChecker) HTTPRequestChecker
 	}
 
 	// HTTPResponseCheckerProvider provides checks on type *http.Response.
@@ -267,6 +305,8 @@ var (
 	Context ContextCheckerProvider = contextCheckerProvider{}
 	// Duration implements DurationCheckerProvider.
 	Duration DurationCheckerProvider = durationCheckerProvider{}
+	// DurationStats implements DurationStatsCheckerProvider.
+	DurationStats DurationStatsCheckerProvider = durationStatsCheckerProvider{}
 	// Float64 implements Float64CheckerProvider.
 	Float64 Float64CheckerProvider = float64CheckerProvider{}
 	// HTTPHeader implements HTTPHeaderCheckerProvider.
@@ -0,0 +1,156 @@
+package check
+
+import (
+	"fmt"
+	"math"
+	"sort"
+	"time"
+)
+
+// durationStatsCheckerProvider provides checks on type []time.Duration.
+// The gotten slice is read as a set of duration samples, such as the ones
+// collected by a repeated measure.
+type durationStatsCheckerProvider struct{ baseCheckerProvider }
+
+// Mean checks the mean of the gotten samples passes the input
+// DurationChecker.
+func (p durationStatsCheckerProvider) Mean(c DurationChecker) DurationStatsChecker {
+	return p.statChecker("mean", func(s durationStats) time.Duration { return s.mean }, c)
+}
+
+// Median checks the median of the gotten samples passes the input
+// DurationChecker.
+func (p durationStatsCheckerProvider) Median(c DurationChecker) DurationStatsChecker {
+	return p.statChecker("median", func(s durationStats) time.Duration { return s.median }, c)
+}
+
+// Percentile checks the percentile pct of the gotten samples passes
+// the input DurationChecker, using the nearest-rank method.
+// It panics if pct is not in range [0:100].
+func (p durationStatsCheckerProvider) Percentile(pct float64, c DurationChecker) DurationStatsChecker {
+	if pct < 0 || pct > 100 {
+		panic(fmt.Sprintf("DurationStats.Percentile: exp 0 <= pct <= 100, got %v", pct))
+	}
+	return p.statChecker(
+		fmt.Sprintf("p%v", pct),
+		func(s durationStats) time.Duration { return s.percentile(pct) },
+		c,
+	)
+}
+
+// P95 checks the 95th percentile of the gotten samples passes the input
+// DurationChecker.
+func (p durationStatsCheckerProvider) P95(c DurationChecker) DurationStatsChecker {
+	return p.Percentile(95, c)
+}
+
+// P99 checks the 99th percentile of the gotten samples passes the input
+// DurationChecker.
+func (p durationStatsCheckerProvider) P99(c DurationChecker) DurationStatsChecker {
+	return p.Percentile(99, c)
+}
+
+// Max checks the maximum of the gotten samples passes the input
+// DurationChecker.
+func (p durationStatsCheckerProvider) Max(c DurationChecker) DurationStatsChecker {
+	return p.statChecker("max", func(s durationStats) time.Duration { return s.max }, c)
+}
+
+// StdDev checks the standard deviation of the gotten samples passes
+// the input DurationChecker.
+func (p durationStatsCheckerProvider) StdDev(c DurationChecker) DurationStatsChecker {
+	return p.statChecker("stddev", func(s durationStats) time.Duration { return s.stddev }, c)
+}
+
+// Helpers
+
+// statChecker returns a DurationStatsChecker passing if the stat computed
+// by get passes c. It fails if there are no samples.
+func (p durationStatsCheckerProvider) statChecker(
+	name string,
+	get func(s durationStats) time.Duration,
+	c DurationChecker,
+) DurationStatsChecker {
+	var stats durationStats
+	pass := func(got []time.Duration) bool {
+		stats = newDurationStats(got)
+		return stats.n != 0 && c.Pass(get(stats))
+	}
+	expl := func(label string, _ interface{}) string {
+		if stats.n == 0 {
+			return p.explain(label, name+" to pass DurationChecker", "no samples")
+		}
+		return p.explainCheck(label,
+			name+" to pass DurationChecker",
+			c.Explain(name, get(stats))+"\n"+stats.String(),
+		)
+	}
+	return NewDurationStatsChecker(pass, expl)
+}
+
+// durationStats is a summary of the distribution of duration samples.
+type durationStats struct {
+	sorted []time.Duration
+
+	n                      int
+	min, max, mean, median time.Duration
+	p95, p99, stddev       time.Duration
+}
+
+func newDurationStats(samples []time.Duration) durationStats {
+	n := len(samples)
+	if n == 0 {
+		return durationStats{}
+	}
+
+	sorted := make([]time.Duration, n)
+	copy(sorted, samples)
+	sort.Slice(sorted, func(i, j int) bool { return sorted[i] < sorted[j] })
+
+	var sum float64
+	for _, d := range sorted {
+		sum += float64(d)
+	}
+	mean := sum / float64(n)
+
+	var sqdiff float64
+	for _, d := range sorted {
+		sqdiff += (float64(d) - mean) * (float64(d) - mean)
+	}
+
+	median := sorted[n/2]
+	if n%2 == 0 {
+		median = (sorted[n/2-1] + sorted[n/2]) / 2
+	}
+
+	s := durationStats{
+		sorted: sorted,
+		n:      n,
+		min:    sorted[0],
+		max:    sorted[n-1],
+		mean:   time.Duration(mean),
+		median: median,
+		stddev: time.Duration(math.Sqrt(sqdiff / float64(n))),
+	}
+	s.p95, s.p99 = s.percentile(95), s.percentile(99)
+	return s
+}
+
+// percentile returns the pth percentile of the samples
+// using the nearest-rank method.
+func (s durationStats) percentile(p float64) time.Duration {
+	rank := int(math.Ceil(p / 100 * float64(s.n)))
+	if rank < 1 {
+		rank = 1
+	}
+	return s.sorted[rank-1]
+}
+
+// String returns the summary of the distribution in format:
+// 	distribution of <n> samples: min <min>, mean <mean>, ...
+func (s durationStats) String() string {
+	return fmt.Sprintf(
+		"distribution of %d samples: min %v, mean %v, median %v, p95 %v, p99 %v, max %v, stddev %v",
+		s.n, s.min, s.mean, s.median, s.p95, s.p99, s.max, s.stddev,
+	)
+}
@@ -0,0 +1,130 @@
+package check_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/drykit-go/testx/check"
+	"github.com/drykit-go/testx/internal/testutil"
+)
+
+func TestDurationStatsCheckerProvider(t *testing.T) {
+	ms := time.Millisecond
+	samples := []time.Duration{5 * ms, 1 * ms, 3 * ms, 2 * ms, 4 * ms, 10 * ms, 6 * ms, 8 * ms, 7 * ms, 9 * ms}
+	summary := "distribution of 10 samples: min 1ms, mean 5.5ms, median 5.5ms, " +
+		"p95 10ms, p99 10ms, max 10ms, stddev 2.872281ms"
+
+	t.Run("Mean pass", func(t *testing.T) {
+		c := check.DurationStats.Mean(check.Duration.InRange(5*ms, 6*ms))
+		assertPassDurationStatsChecker(t, "Mean", c, samples)
+	})
+
+	t.Run("Mean fail", func(t *testing.T) {
+		c := check.DurationStats.Mean(check.Duration.Under(5 * ms))
+		assertFailDurationStatsChecker(t, "Mean", c, samples, makeExpl(
+			"mean to pass DurationChecker",
+			"explanation: mean:\n"+makeExpl("under 5ms", "5ms")+"\n"+summary,
+		))
+	})
+
+	t.Run("Median pass", func(t *testing.T) {
+		c := check.DurationStats.Median(check.Duration.InRange(5*ms, 6*ms))
+		assertPassDurationStatsChecker(t, "Median", c, samples)
+		c = check.DurationStats.Median(check.Duration.InRange(3*ms, 3*ms))
+		assertPassDurationStatsChecker(t, "Median", c, samples[:5])
+	})
+
+	t.Run("Median fail", func(t *testing.T) {
+		c := check.DurationStats.Median(check.Duration.Over(6 * ms))
+		assertFailDurationStatsChecker(t, "Median", c, samples, makeExpl(
+			"median to pass DurationChecker",
+			"explanation: median:\n"+makeExpl("over 6ms", "5ms")+"\n"+summary,
+		))
+	})
+
+	t.Run("Percentile pass", func(t *testing.T) {
+		c := check.DurationStats.Percentile(50, check.Duration.InRange(5*ms, 5*ms))
+		assertPassDurationStatsChecker(t, "Percentile", c, samples)
+		c = check.DurationStats.Percentile(0, check.Duration.InRange(ms, ms))
+		assertPassDurationStatsChecker(t, "Percentile", c, samples)
+		c = check.DurationStats.P95(check.Duration.InRange(10*ms, 10*ms))
+		assertPassDurationStatsChecker(t, "P95", c, samples)
+		c = check.DurationStats.P99(check.Duration.InRange(10*ms, 10*ms))
+		assertPassDurationStatsChecker(t, "P99", c, samples)
+	})
+
+	t.Run("Percentile fail", func(t *testing.T) {
+		c := check.DurationStats.P95(check.Duration.Under(10 * ms))
+		assertFailDurationStatsChecker(t, "P95", c, samples, makeExpl(
+			"p95 to pass DurationChecker",
+			"explanation: p95:\n"+makeExpl("under 10ms", "10ms")+"\n"+summary,
+		))
+	})
+
+	t.Run("Percentile bad input", func(t *testing.T) {
+		for _, p := range []float64{-1, 101} {
+			func() {
+				defer testutil.AssertPanic(t)
+				check.DurationStats.Percentile(p, check.Duration.Under(ms))
+			}()
+		}
+	})
+
+	t.Run("Max pass", func(t *testing.T) {
+		c := check.DurationStats.Max(check.Duration.InRange(10*ms, 10*ms))
+		assertPassDurationStatsChecker(t, "Max", c, samples)
+	})
+
+	t.Run("Max fail", func(t *testing.T) {
+		c := check.DurationStats.Max(check.Duration.Under(10 * ms))
+		assertFailDurationStatsChecker(t, "Max", c, samples, makeExpl(
+			"max to pass DurationChecker",
+			"explanation: max:\n"+makeExpl("under 10ms", "10ms")+"\n"+summary,
+		))
+	})
+
+	t.Run("StdDev pass", func(t *testing.T) {
+		c := check.DurationStats.StdDev(check.Duration.InRange(2*ms, 3*ms))
+		assertPassDurationStatsChecker(t, "StdDev", c, samples)
+		c = check.DurationStats.StdDev(check.Duration.InRange(0, 0))
+		assertPassDurationStatsChecker(t, "StdDev", c, []time.Duration{ms, ms})
+	})
+
+	t.Run("StdDev fail", func(t *testing.T) {
+		c := check.DurationStats.StdDev(check.Duration.Under(ms))
+		assertFailDurationStatsChecker(t, "StdDev", c, samples, makeExpl(
+			"stddev to pass DurationChecker",
+			"explanation: stddev:\n"+makeExpl("under 1ms", "2ms")+"\n"+summary,
+		))
+	})
+
+	t.Run("no samples", func(t *testing.T) {
+		c := check.DurationStats.Max(check.Duration.Under(ms))
+		assertFailDurationStatsChecker(t, "Max", c, nil, makeExpl(
+			"max to pass DurationChecker",
+			"no samples",
+		))
+	})
+}
+
+// Helpers
+
+func assertPassDurationStatsChecker(t *testing.T, method string, c check.DurationStatsChecker, samples []time.Duration) {
+	t.Helper()
+	if !c.Pass(samples) {
+		failDurationStatsCheckerTest(t, true, method, samples, c.Explain)
+	}
+}
+
+func assertFailDurationStatsChecker(t *testing.T, method string, c check.DurationStatsChecker, samples []time.Duration, expexpl string) {
+	t.Helper()
+	if c.Pass(samples) {
+		failDurationStatsCheckerTest(t, false, method, samples, c.Explain)
+	}
+	assertGoodExplain(t, c, samples, expexpl)
+}
+
+func failDurationStatsCheckerTest(t *testing.T, expPass bool, method string, samples []time.Duration, explain check.ExplainFunc) {
+	t.Helper()
+	failCheckerTest(t, expPass, "DurationStats."+method, explain("DurationStats value", samples))
+}
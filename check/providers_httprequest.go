@@ -2,6 +2,7 @@ package check
 
 import (
 	"context"
+	"fmt"
 	"net/http"
 
 	"github.com/drykit-go/testx/internal/ioutil"
@@ -69,3 +70,55 @@ func (p httpRequestCheckerProvider) Context(c ContextChecker) HTTPRequestChecker
 	}
 	return NewHTTPRequestChecker(pass, expl)
 }
+
+// Method checks the gotten *http.Request Method passes
+// the input StringChecker.
+func (p httpRequestCheckerProvider) Method(c StringChecker) HTTPRequestChecker {
+	var method string
+	pass := func(got *http.Request) bool {
+		method = got.Method
+		return c.Pass(method)
+	}
+	expl := func(label string, _ interface{}) string {
+		return p.explainCheck(label,
+			"method to pass StringChecker",
+			c.Explain("method", method),
+		)
+	}
+	return NewHTTPRequestChecker(pass, expl)
+}
+
+// Path checks the gotten *http.Request URL path passes
+// the input StringChecker.
+func (p httpRequestCheckerProvider) Path(c StringChecker) HTTPRequestChecker {
+	var path string
+	pass := func(got *http.Request) bool {
+		path = got.URL.Path
+		return c.Pass(path)
+	}
+	expl := func(label string, _ interface{}) string {
+		return p.explainCheck(label,
+			"path to pass StringChecker",
+			c.Explain("path", path),
+		)
+	}
+	return NewHTTPRequestChecker(pass, expl)
+}
+
+// Query checks the gotten *http.Request URL query value for the given key
+// passes the input StringChecker. It only checks the first value
+// for the given key, an empty string if there are none.
+func (p httpRequestCheckerProvider) Query(key string, c StringChecker) HTTPRequestChecker {
+	var val string
+	pass := func(got *http.Request) bool {
+		val = got.URL.Query().Get(key)
+		return c.Pass(val)
+	}
+	expl := func(label string, _ interface{}) string {
+		return p.explainCheck(label,
+			fmt.Sprintf("query value for key %q to pass StringChecker", key),
+			c.Explain("query value", val),
+		)
+	}
+	return NewHTTPRequestChecker(pass, expl)
+}
@@ -97,6 +97,47 @@ func TestHTTPRequestCheckerProvider(t *testing.T) {
 			),
 		))
 	})
+
+	t.Run("Method pass", func(t *testing.T) {
+		c := check.HTTPRequest.Method(check.String.Is("GET"))
+		assertPassHTTPRequestChecker(t, "Method", c, newReq())
+	})
+
+	t.Run("Method fail", func(t *testing.T) {
+		c := check.HTTPRequest.Method(check.String.Is("POST"))
+		assertFailHTTPRequestChecker(t, "Method", c, newReq(), makeExpl(
+			"method to pass StringChecker",
+			"explanation: method:\n"+makeExpl("POST", "GET"),
+		))
+	})
+
+	t.Run("Path pass", func(t *testing.T) {
+		c := check.HTTPRequest.Path(check.String.Is("/endpoint"))
+		assertPassHTTPRequestChecker(t, "Path", c, newReq())
+	})
+
+	t.Run("Path fail", func(t *testing.T) {
+		c := check.HTTPRequest.Path(check.String.Contains("users"))
+		assertFailHTTPRequestChecker(t, "Path", c, newReq(), makeExpl(
+			"path to pass StringChecker",
+			"explanation: path:\n"+makeExpl("to contain substring users", "/endpoint"),
+		))
+	})
+
+	t.Run("Query pass", func(t *testing.T) {
+		c := check.HTTPRequest.Query("id", check.String.Is("42"))
+		assertPassHTTPRequestChecker(t, "Query", c, newReq())
+		c = check.HTTPRequest.Query("name", check.String.Is(""))
+		assertPassHTTPRequestChecker(t, "Query", c, newReq())
+	})
+
+	t.Run("Query fail", func(t *testing.T) {
+		c := check.HTTPRequest.Query("id", check.String.Is("43"))
+		assertFailHTTPRequestChecker(t, "Query", c, newReq(), makeExpl(
+			"query value for key \"id\" to pass StringChecker",
+			"explanation: query value:\n"+makeExpl("43", "42"),
+		))
+	})
 }
 
 // Helpers
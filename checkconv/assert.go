@@ -67,6 +67,15 @@ func FromDuration(c check.DurationChecker) check.ValueChecker {
 	)
 }
 
+// FromDurationStats returns a check.ValueChecker that wraps the given
+// check.DurationStatsChecker, so it can be used as a generic checker.
+func FromDurationStats(c check.DurationStatsChecker) check.ValueChecker {
+	return check.NewValueChecker(
+		func(got interface{}) bool { return c.Pass(got.([]time.Duration)) },
+		c.Explain,
+	)
+}
+
 // FromContext returns a check.ValueChecker that wraps the given
 // check.ContextChecker, so it can be used as a generic checker.
 func FromContext(c check.ContextChecker) check.ValueChecker {
@@ -125,6 +134,8 @@ func Assert(knownChecker interface{}) check.ValueChecker {
 		return FromFloat64(c)
 	case check.DurationChecker:
 		return FromDuration(c)
+	case check.DurationStatsChecker:
+		return FromDurationStats(c)
 	case check.ContextChecker:
 		return FromContext(c)
 	case check.HTTPHeaderChecker:
@@ -67,6 +67,7 @@ func TestAssertMany(t *testing.T) {
 			{checker: check.Int.Is(1), in: 1},
 			{checker: check.Float64.Is(1), in: 1.},
 			{checker: check.Duration.Over(time.Millisecond), in: time.Second},
+			{checker: check.DurationStats.Max(check.Duration.Over(time.Millisecond)), in: []time.Duration{time.Second}},
 			{checker: check.Context.Done(true), in: ctxDone()},
 			{checker: check.HTTPHeader.HasKey("a"), in: http.Header{"a": []string{"b"}}},
 			{checker: check.HTTPRequest.ContentLength(check.Int.GT(1)), in: &http.Request{ContentLength: 2}},
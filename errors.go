@@ -3,16 +3,51 @@ package testx
 import (
 	"errors"
 	"fmt"
+	"reflect"
 )
 
 var (
 	// errTableRunnerConfig is returned when TableRunner is provided
 	// a TableConfig that is invalid or incompatible with the tested func.
 	errTableRunnerConfig = errors.New("invalid TableConfig")
+	// errTableRunnerCase is returned when TableRunner is provided
+	// a Case that is invalid or incompatible with the tested func.
+	errTableRunnerCase = errors.New("invalid Case")
+	// errTableRunnerCasesJSON is returned when TableRunner fails
+	// to load cases from a JSON source.
+	errTableRunnerCasesJSON = errors.New("invalid JSON cases")
 	// errTableRunnerFunc is returned when TableRunner is initialized
 	// with an incompatible function (most likely it doesn't accept
 	// parameters or doesn't return any values).
 	errTableRunnerFunc = errors.New("invalid Table func")
+	// errTableRunnerMethodRecv is returned when TableRunner is initialized
+	// via Method with a nil receiver.
+	errTableRunnerMethodRecv = fmt.Errorf(
+		"%w: receiver must be a typed value",
+		errTableRunnerFunc,
+	)
+	// errTableRunnerMethodName is returned when TableRunner is initialized
+	// via Method with a method name that does not exist on the receiver.
+	errTableRunnerMethodName = fmt.Errorf(
+		"%w: no exported method with this name",
+		errTableRunnerFunc,
+	)
+	// errTableRunnerOracle is returned when TableRunner is provided
+	// an oracle func that is not a func or is incompatible with
+	// the tested func.
+	errTableRunnerOracle = errors.New("invalid Oracle func")
+	// errCallRunnerFunc is returned when CallRunner is initialized
+	// with a value that is not a func or args it cannot be called with.
+	errCallRunnerFunc = errors.New("invalid Call func")
+	// errChanRunnerChan is returned when ChanRunner is initialized
+	// with a value that is not a non-nil receivable channel.
+	errChanRunnerChan = errors.New("invalid Chan channel: exp a non-nil receivable channel")
+	// errSamplingCount is returned when a runner is provided
+	// an invalid number of runs via Repeat or Warmup.
+	errSamplingCount = errors.New("invalid number of runs")
+	// errRequestBuilder is returned when RequestBuilder fails
+	// to build a request.
+	errRequestBuilder = errors.New("invalid NewRequest request")
 	// errTableRunnerFuncNumIn is returned when TableRunner is initialized
 	// with a function that doesn't accept parameters.
 	errTableRunnerFuncNumIn = fmt.Errorf(
@@ -50,3 +85,109 @@ func errTableRunnerConfigOutPos(funcName string, outPos, numOut int) error {
 func errTableRunnerConfigFixedArgs(n int) error {
 	return fmt.Errorf("%w: invalid FixedArgs number: %d", errTableRunnerConfig, n)
 }
+
+// errTableRunnerCaseArgs returns an error reporting invalid arguments
+// for the ith Case.
+func errTableRunnerCaseArgs(funcName string, caseID int, err error) error {
+	return fmt.Errorf(
+		"%w: Cases[%d]: cannot call %s: %v",
+		errTableRunnerCase, caseID, funcName, err,
+	)
+}
+
+// errTableRunnerCaseExpAll returns an error reporting an invalid number
+// of values for Case.ExpAll.
+func errTableRunnerCaseExpAll(funcName string, caseID, n, numOut int) error {
+	return fmt.Errorf(
+		"%w: Cases[%d]: ExpAll: exp %d values (number of values returned by %s), got %d",
+		errTableRunnerCase, caseID, numOut, funcName, n,
+	)
+}
+
+// errTableRunnerCaseOutPos returns an error reporting an invalid
+// return value position in Case.Outs.
+func errTableRunnerCaseOutPos(funcName string, caseID, pos, numOut int) error {
+	return fmt.Errorf(
+		"%w: Cases[%d]: Outs: exp 0 <= n < %d (number of values returned by %s), got %d",
+		errTableRunnerCase, caseID, numOut, funcName, pos,
+	)
+}
+
+// errTableRunnerCaseNoErrOut returns an error reporting error expectations
+// set on a Case while the tested func does not return a trailing error.
+func errTableRunnerCaseNoErrOut(funcName string, caseID int) error {
+	return fmt.Errorf(
+		"%w: Cases[%d]: error expectations set but %s does not return a trailing error",
+		errTableRunnerCase, caseID, funcName,
+	)
+}
+
+// errTableRunnerCaseErrAs returns an error reporting an invalid value
+// for Case.ErrAs.
+func errTableRunnerCaseErrAs(caseID int, target interface{}) error {
+	return fmt.Errorf(
+		"%w: Cases[%d]: ErrAs: exp a non-nil pointer to an error or interface type, got %T",
+		errTableRunnerCase, caseID, target,
+	)
+}
+
+// errTableRunnerCombineEmpty returns an error reporting an empty list
+// of candidate values for the ith parameter of the tested func
+// provided to Combine or CombinePairwise.
+func errTableRunnerCombineEmpty(method, funcName string, i int) error {
+	return fmt.Errorf(
+		"%w: %s: values[%d]: exp at least 1 candidate value for parameter %d of %s, got none",
+		errTableRunnerCase, method, i, i, funcName,
+	)
+}
+
+// errTableRunnerOracleType returns an error reporting an oracle func
+// whose type does not match the one of the tested func.
+func errTableRunnerOracleType(oracleName string, oracleType, funcType reflect.Type) error {
+	return fmt.Errorf(
+		"%w: %s: exp type %s (type of the tested func), got %s",
+		errTableRunnerOracle, oracleName, funcType, oracleType,
+	)
+}
+
+// errCallRunnerOutPos returns an error reporting an invalid
+// return value position for CallRunner.Out.
+func errCallRunnerOutPos(funcName string, pos, numOut int) error {
+	return fmt.Errorf(
+		"%w: Out: exp 0 <= n < %d (number of values returned by %s), got %d",
+		errCallRunnerFunc, numOut, funcName, pos,
+	)
+}
+
+// errCallRunnerNoErrOut returns an error reporting a call to CallRunner.Err
+// while the tested func does not return a trailing error.
+func errCallRunnerNoErrOut(funcName string) error {
+	return fmt.Errorf("%w: Err: %s does not return a trailing error", errCallRunnerFunc, funcName)
+}
+
+// errSamplingCountMin returns an error reporting a number of runs
+// lower than min for the given sampling method.
+func errSamplingCountMin(method string, n, min int) error {
+	return fmt.Errorf("%w: %s: exp n >= %d, got %d", errSamplingCount, method, min, n)
+}
+
+// errTableRunnerJSON returns an error reporting a JSON source of cases
+// that could not be decoded.
+func errTableRunnerJSON(source string, err error) error {
+	return fmt.Errorf("%w: %s: %v", errTableRunnerCasesJSON, source, err)
+}
+
+// errTableRunnerJSONCase returns an error reporting a JSON case
+// that could not be converted to a Case.
+func errTableRunnerJSONCase(source string, caseID int, err error) error {
+	return fmt.Errorf("%w: %s: Cases[%d]: %v", errTableRunnerCasesJSON, source, caseID, err)
+}
+
+// errTableRunnerCaseReceiver returns an error reporting a Case.Receiver
+// set while the tested func is not a method.
+func errTableRunnerCaseReceiver(funcName string, caseID int) error {
+	return fmt.Errorf(
+		"%w: Cases[%d]: Receiver: %s is not a method, use testx.Method",
+		errTableRunnerCase, caseID, funcName,
+	)
+}
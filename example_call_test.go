@@ -0,0 +1,46 @@
+package testx_test
+
+import (
+	"fmt"
+	"strconv"
+	"testing"
+
+	"github.com/drykit-go/testx"
+	"github.com/drykit-go/testx/check"
+	"github.com/drykit-go/testx/checkconv"
+)
+
+/*
+	CallRunner
+*/
+
+func ExampleCallRunner() {
+	t := &testing.T{} // ignore: emulating a testing context
+
+	testx.Call(strconv.Atoi, "42").
+		Out(0, check.Value.Is(42)). // pass
+		Err(check.Value.Is(nil)).   // pass
+		NotPanics().                // pass
+		Run(t)
+}
+
+func ExampleCallRunner_dryRun() {
+	mustAtoi := func(s string) int {
+		n, err := strconv.Atoi(s)
+		if err != nil {
+			panic(err.Error())
+		}
+		return n
+	}
+
+	results := testx.Call(mustAtoi, "4x").
+		Panics(checkconv.FromString(check.String.Contains("invalid syntax"))). // pass
+		DryRun()
+
+	fmt.Println(results.Passed())
+	fmt.Println(results.Panic())
+
+	// Output:
+	// true
+	// strconv.Atoi: parsing "4x": invalid syntax
+}
@@ -55,3 +55,26 @@ func ExampleTable_dyadic() {
 		{In: 0.0, Exp: errors.New("division by 0")}, // divide(42.0, 0.0) -> (_, err)
 	}).Run(t)
 }
+
+func ExampleTable_args() {
+	t := &testing.T{} // ignore: emulating a testing context
+
+	// clamp is the func to be tested.
+	clamp := func(v, lo, hi int) int {
+		if v < lo {
+			return lo
+		}
+		if v > hi {
+			return hi
+		}
+		return v
+	}
+
+	// Case.Args provides all the arguments of the tested func
+	// for each case, so no config is needed.
+	testx.Table(clamp).Cases([]testx.Case{
+		{Args: testx.Args{5, 0, 10}, Exp: 5},   // clamp(5, 0, 10) -> 5
+		{Args: testx.Args{5, 6, 10}, Exp: 6},   // clamp(5, 6, 10) -> 6
+		{Args: testx.Args{50, 0, 10}, Exp: 10}, // clamp(50, 0, 10) -> 10
+	}).Run(t)
+}
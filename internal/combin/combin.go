@@ -0,0 +1,146 @@
+// Package combin provides combinatorial generators of index tuples.
+package combin
+
+// Product returns the cartesian product of ranges [0, sizes[i]),
+// each tuple holding one index per range. The last index varies
+// the fastest. It returns nil if sizes is empty or has a zero value.
+func Product(sizes []int) [][]int {
+	if !valid(sizes) {
+		return nil
+	}
+	var tuples [][]int
+	tuple := make([]int, len(sizes))
+	for {
+		tuples = append(tuples, append([]int(nil), tuple...))
+		// increment tuple as a mixed radix number
+		i := len(sizes) - 1
+		for ; i >= 0; i-- {
+			tuple[i]++
+			if tuple[i] < sizes[i] {
+				break
+			}
+			tuple[i] = 0
+		}
+		if i < 0 {
+			return tuples
+		}
+	}
+}
+
+// Pairwise returns a set of tuples of indexes in ranges [0, sizes[i])
+// covering every pair of indexes of any two ranges at least once.
+// It is generally much smaller than the cartesian product, though
+// not guaranteed to be minimal. The result is deterministic.
+// It returns Product(sizes) if there are less than 3 ranges.
+func Pairwise(sizes []int) [][]int {
+	if len(sizes) < 3 || !valid(sizes) {
+		return Product(sizes)
+	}
+
+	uncovered := newPairSet(sizes)
+	var tuples [][]int
+	for uncovered.len() != 0 {
+		tuple := make([]int, len(sizes))
+		for i := range tuple {
+			tuple[i] = -1
+		}
+		// seed the tuple with the first uncovered pair
+		p := uncovered.first()
+		tuple[p.i], tuple[p.j] = p.a, p.b
+		// fill the other positions greedily
+		for i := range tuple {
+			if tuple[i] != -1 {
+				continue
+			}
+			best, bestScore := 0, -1
+			for v := 0; v < sizes[i]; v++ {
+				if score := uncovered.covered(tuple, i, v); score > bestScore {
+					best, bestScore = v, score
+				}
+			}
+			tuple[i] = best
+		}
+		uncovered.remove(tuple)
+		tuples = append(tuples, tuple)
+	}
+	return tuples
+}
+
+func valid(sizes []int) bool {
+	if len(sizes) == 0 {
+		return false
+	}
+	for _, n := range sizes {
+		if n <= 0 {
+			return false
+		}
+	}
+	return true
+}
+
+// pair is the association of index a in range i with index b
+// in range j, with i < j.
+type pair struct{ i, j, a, b int }
+
+// pairSet is an ordered set of pairs.
+type pairSet struct {
+	pairs []pair
+	has   map[pair]bool
+}
+
+func newPairSet(sizes []int) *pairSet {
+	s := &pairSet{has: map[pair]bool{}}
+	for i := range sizes {
+		for j := i + 1; j < len(sizes); j++ {
+			for a := 0; a < sizes[i]; a++ {
+				for b := 0; b < sizes[j]; b++ {
+					p := pair{i: i, j: j, a: a, b: b}
+					s.pairs = append(s.pairs, p)
+					s.has[p] = true
+				}
+			}
+		}
+	}
+	return s
+}
+
+func (s *pairSet) len() int {
+	return len(s.has)
+}
+
+// first returns the first pair of the set in creation order.
+func (s *pairSet) first() pair {
+	for _, p := range s.pairs {
+		if s.has[p] {
+			return p
+		}
+	}
+	panic("combin: first called on an empty pairSet")
+}
+
+// covered returns the number of pairs of the set that setting index v
+// at position i of tuple would cover, considering only the positions
+// already set.
+func (s *pairSet) covered(tuple []int, i, v int) int {
+	n := 0
+	for k, w := range tuple {
+		switch {
+		case w == -1 || k == i:
+			continue
+		case k < i && s.has[pair{i: k, j: i, a: w, b: v}]:
+			n++
+		case k > i && s.has[pair{i: i, j: k, a: v, b: w}]:
+			n++
+		}
+	}
+	return n
+}
+
+// remove removes the pairs covered by tuple from the set.
+func (s *pairSet) remove(tuple []int) {
+	for i := range tuple {
+		for j := i + 1; j < len(tuple); j++ {
+			delete(s.has, pair{i: i, j: j, a: tuple[i], b: tuple[j]})
+		}
+	}
+}
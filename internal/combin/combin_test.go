@@ -0,0 +1,84 @@
+package combin_test
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/drykit-go/testx/internal/combin"
+)
+
+func TestProduct(t *testing.T) {
+	t.Run("all tuples in order", func(t *testing.T) {
+		exp := [][]int{
+			{0, 0, 0}, {0, 0, 1}, {0, 0, 2},
+			{1, 0, 0}, {1, 0, 1}, {1, 0, 2},
+		}
+		if got := combin.Product([]int{2, 1, 3}); !reflect.DeepEqual(got, exp) {
+			t.Errorf("\nexp %v\ngot %v", exp, got)
+		}
+	})
+
+	t.Run("empty ranges", func(t *testing.T) {
+		for _, sizes := range [][]int{nil, {}, {2, 0, 3}} {
+			if got := combin.Product(sizes); got != nil {
+				t.Errorf("exp nil for %v, got %v", sizes, got)
+			}
+		}
+	})
+}
+
+func TestPairwise(t *testing.T) {
+	t.Run("less than 3 ranges", func(t *testing.T) {
+		exp := combin.Product([]int{3, 2})
+		if got := combin.Pairwise([]int{3, 2}); !reflect.DeepEqual(got, exp) {
+			t.Errorf("\nexp %v\ngot %v", exp, got)
+		}
+	})
+
+	for _, sizes := range [][]int{
+		{2, 2, 2},
+		{3, 3, 3, 3},
+		{5, 1, 4, 2, 3},
+		{2, 2, 2, 2, 2, 2, 2, 2, 2, 2},
+	} {
+		got := combin.Pairwise(sizes)
+		assertAllPairsCovered(t, sizes, got)
+		if n, max := len(got), len(combin.Product(sizes)); n > max {
+			t.Errorf("%v: exp at most %d tuples, got %d", sizes, max, n)
+		}
+	}
+
+	t.Run("smaller than product", func(t *testing.T) {
+		sizes := []int{3, 3, 3, 3}
+		if n := len(combin.Pairwise(sizes)); n >= 81 {
+			t.Errorf("exp less than 81 tuples, got %d", n)
+		}
+	})
+}
+
+func assertAllPairsCovered(t *testing.T, sizes []int, tuples [][]int) {
+	t.Helper()
+	type pair struct{ i, j, a, b int }
+	covered := map[pair]bool{}
+	for _, tuple := range tuples {
+		for i := range tuple {
+			if tuple[i] < 0 || tuple[i] >= sizes[i] {
+				t.Fatalf("%v: index out of range in tuple %v", sizes, tuple)
+			}
+			for j := i + 1; j < len(tuple); j++ {
+				covered[pair{i, j, tuple[i], tuple[j]}] = true
+			}
+		}
+	}
+	for i := range sizes {
+		for j := i + 1; j < len(sizes); j++ {
+			for a := 0; a < sizes[i]; a++ {
+				for b := 0; b < sizes[j]; b++ {
+					if !covered[pair{i, j, a, b}] {
+						t.Errorf("%v: pair %v not covered", sizes, pair{i, j, a, b})
+					}
+				}
+			}
+		}
+	}
+}
@@ -3,6 +3,8 @@ package fmtexpl
 
 import (
 	"fmt"
+	"strings"
+	"time"
 
 	"github.com/drykit-go/cond"
 )
@@ -25,6 +27,25 @@ func Checker(label, expStr, gotExpl string) string {
 	return Default(label, expStr, "explanation: "+gotExpl)
 }
 
+// CallLabel returns the label for a call to a func
+// in format: <fname>(<args>)
+//
+// Example:
+// 	`divide(42, 0)`
+func CallLabel(fname string, args fmt.Stringer) string {
+	return fmt.Sprintf("%s(%v)", fname, args)
+}
+
+// PollLabel returns the label for a check on the last value polled
+// by testx.Eventually or testx.Consistently, in format:
+// <label> (attempt <attempts> after <elapsed>)
+//
+// Example:
+// 	`eventually value (attempt 12 after 120ms)`
+func PollLabel(label string, attempts int, elapsed time.Duration) string {
+	return fmt.Sprintf("%s (attempt %d after %v)", label, attempts, elapsed)
+}
+
 // TableCaseLabel returns the label for a testx.Table test case
 // in format: Case <caseID> "<caseLab>" <fname>(<caseIn>)
 //
@@ -37,7 +58,71 @@ func TableCaseLabel(
 	caseLab string,
 	args fmt.Stringer,
 ) string {
-	fcall := fmt.Sprintf("%s(%v)", fname, args)
+	fcall := CallLabel(fname, args)
 	label := cond.String(fmt.Sprintf(` "%s"`, caseLab), "", caseLab != "")
 	return fmt.Sprintf("Table.Cases[%d]%s %s", caseID, label, fcall)
 }
+
+// TableCaseMethodLabel returns the label for a testx.Method test case
+// in format: Case <caseID> "<caseLab>" <recvType>.<method>(<caseIn>)
+// The receiver is represented by its type so the label is stable
+// across runs and can be used to select a subtest.
+//
+// Examples:
+// 	`Table.Cases[2] (*cache.Cache).Get("k")`
+// 	`Table.Cases[2] "empty cache" (*cache.Cache).Get("k")`
+func TableCaseMethodLabel(
+	recv interface{},
+	method string,
+	caseID int,
+	caseLab string,
+	args fmt.Stringer,
+) string {
+	recvType := fmt.Sprintf("%T", recv)
+	if strings.HasPrefix(recvType, "*") {
+		recvType = "(" + recvType + ")"
+	}
+	return TableCaseLabel(recvType+"."+method, caseID, caseLab, args)
+}
+
+// TableCaseOutLabel returns the label for a check on the return value
+// at position pos of a testx.Table test case, in format:
+// <caseLabel> out[<pos>]
+//
+// Example:
+// 	`Table.Cases[2] parse("42") out[1]`
+func TableCaseOutLabel(caseLabel string, pos int) string {
+	return fmt.Sprintf("%s out[%d]", caseLabel, pos)
+}
+
+// TableCasePanicLabel returns the label for a check on the value
+// recovered from a panic in a testx.Table test case, in format:
+// <caseLabel> panic
+//
+// Example:
+// 	`Table.Cases[2] mustParse("") panic`
+func TableCasePanicLabel(caseLabel string) string {
+	return caseLabel + " panic"
+}
+
+// TableCaseHookLabel returns the label for a check on the value
+// recovered from a panic in a hook of a testx.Table test case,
+// in format:
+// <caseLabel> <hook>
+//
+// Example:
+// 	`Table.Cases[2] countFiles("/tmp") Setup`
+func TableCaseHookLabel(caseLabel, hook string) string {
+	return caseLabel + " " + hook
+}
+
+// TableCaseOracleLabel returns the label for a check comparing a value
+// returned by the tested func of a testx.Table test case with the one
+// returned by a reference func, in format:
+// <label> vs <oracleName>
+//
+// Example:
+// 	`Table.Cases[2] fastSqrt(9) out[0] vs sqrt`
+func TableCaseOracleLabel(label, oracleName string) string {
+	return fmt.Sprintf("%s vs %s", label, oracleName)
+}
@@ -2,6 +2,7 @@ package fmtexpl_test
 
 import (
 	"testing"
+	"time"
 
 	"github.com/drykit-go/testx"
 	"github.com/drykit-go/testx/internal/fmtexpl"
@@ -31,6 +32,22 @@ func TestChecker(t *testing.T) {
 	}
 }
 
+func TestCallLabel(t *testing.T) {
+	exp := `divide(42, 0)`
+	got := fmtexpl.CallLabel("divide", testx.Args{42, 0})
+	if got != exp {
+		t.Errorf("\nexp %s\ngot %s", exp, got)
+	}
+}
+
+func TestPollLabel(t *testing.T) {
+	exp := `eventually value (attempt 12 after 120ms)`
+	got := fmtexpl.PollLabel("eventually value", 12, 120*time.Millisecond)
+	if got != exp {
+		t.Errorf("\nexp %s\ngot %s", exp, got)
+	}
+}
+
 func TestTableCaseLabel(t *testing.T) {
 	t.Run("with label input", func(t *testing.T) {
 		exp := `Table.Cases[3] "division by 0" divide(42, 0)`
@@ -53,3 +70,61 @@ func TestTableCaseLabel(t *testing.T) {
 		}
 	})
 }
+
+func TestTableCaseMethodLabel(t *testing.T) {
+	type store struct {
+		data map[string]int
+		next *int
+	}
+
+	for _, tc := range []struct {
+		recv interface{}
+		exp  string
+	}{
+		{
+			recv: &store{data: map[string]int{"a": 1}, next: new(int)},
+			exp:  `Table.Cases[0] "filled" (*fmtexpl_test.store).Get("a")`,
+		},
+		{
+			recv: store{},
+			exp:  `Table.Cases[0] "filled" fmtexpl_test.store.Get("a")`,
+		},
+	} {
+		got := fmtexpl.TableCaseMethodLabel(tc.recv, "Get", 0, "filled", testx.Args{"a"})
+		if got != tc.exp {
+			t.Errorf("\nexp %s\ngot %s", tc.exp, got)
+		}
+	}
+}
+
+func TestTableCaseOutLabel(t *testing.T) {
+	exp := `Table.Cases[3] divide(42, 0) out[1]`
+	got := fmtexpl.TableCaseOutLabel(`Table.Cases[3] divide(42, 0)`, 1)
+	if got != exp {
+		t.Errorf("\nexp %s\ngot %s", exp, got)
+	}
+}
+
+func TestTableCaseOracleLabel(t *testing.T) {
+	exp := `Table.Cases[3] fastSqrt(9) out[0] vs sqrt`
+	got := fmtexpl.TableCaseOracleLabel(`Table.Cases[3] fastSqrt(9) out[0]`, "sqrt")
+	if got != exp {
+		t.Errorf("\nexp %s\ngot %s", exp, got)
+	}
+}
+
+func TestTableCasePanicLabel(t *testing.T) {
+	exp := `Table.Cases[3] divide(42, 0) panic`
+	got := fmtexpl.TableCasePanicLabel(`Table.Cases[3] divide(42, 0)`)
+	if got != exp {
+		t.Errorf("\nexp %s\ngot %s", exp, got)
+	}
+}
+
+func TestTableCaseHookLabel(t *testing.T) {
+	exp := `Table.Cases[3] countFiles("/tmp") Setup`
+	got := fmtexpl.TableCaseHookLabel(`Table.Cases[3] countFiles("/tmp")`, "Setup")
+	if got != exp {
+		t.Errorf("\nexp %s\ngot %s", exp, got)
+	}
+}
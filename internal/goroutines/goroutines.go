@@ -0,0 +1,111 @@
+// Package goroutines provides functions to detect leaked goroutines
+// by comparing snapshots of the running goroutines stacks.
+package goroutines
+
+import (
+	"bytes"
+	"runtime"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// pollInterval is the duration between two snapshots while waiting
+// for goroutines to terminate.
+const pollInterval = 10 * time.Millisecond
+
+// ignoredFuncs lists funcs of well-known goroutines that are never
+// reported as leaked: runtime, testing and signal handling goroutines.
+var ignoredFuncs = []string{
+	"testing.RunTests",
+	"testing.(*T).Run",
+	"testing.(*M).",
+	"testing.runFuzzing",
+	"testing.tRunner.func1",
+	"runtime.goexit0",
+	"runtime.ensureSigM",
+	"runtime/trace.Start",
+	"os/signal.signal_recv",
+	"os/signal.loop",
+	"runtime.ReadTrace",
+}
+
+// Snapshot is a set of goroutines stacks indexed by goroutine ID.
+type Snapshot map[int]string
+
+// Take returns a Snapshot of the currently running goroutines.
+func Take() Snapshot {
+	buf := make([]byte, 1<<16)
+	for {
+		n := runtime.Stack(buf, true)
+		if n < len(buf) {
+			return parse(buf[:n])
+		}
+		buf = make([]byte, 2*len(buf))
+	}
+}
+
+// Leaked returns the stacks of the goroutines that are running
+// but were not in s, ignoring well-known runtime and testing goroutines.
+// It waits up to grace for them to terminate before returning.
+// The result is ordered by goroutine ID.
+func (s Snapshot) Leaked(grace time.Duration) []string {
+	deadline := time.Now().Add(grace)
+	for {
+		leaked := s.diff(Take())
+		if len(leaked) == 0 || time.Now().Add(pollInterval).After(deadline) {
+			return leaked
+		}
+		time.Sleep(pollInterval)
+	}
+}
+
+// diff returns the stacks of the goroutines of current that are not in s.
+func (s Snapshot) diff(current Snapshot) []string {
+	ids := []int{}
+	for id, stack := range current {
+		if _, ok := s[id]; !ok && !isIgnored(stack) {
+			ids = append(ids, id)
+		}
+	}
+	sort.Ints(ids)
+	stacks := make([]string, len(ids))
+	for i, id := range ids {
+		stacks[i] = current[id]
+	}
+	return stacks
+}
+
+func isIgnored(stack string) bool {
+	for _, fn := range ignoredFuncs {
+		if strings.Contains(stack, fn) {
+			return true
+		}
+	}
+	return false
+}
+
+// parse parses the output of runtime.Stack for all goroutines.
+// Each goroutine block starts with a line "goroutine <ID> [<state>]:"
+// and blocks are separated by an empty line.
+func parse(b []byte) Snapshot {
+	snap := Snapshot{}
+	for _, block := range bytes.Split(b, []byte("\n\n")) {
+		stack := strings.TrimSpace(string(block))
+		if id, ok := parseID(stack); ok {
+			snap[id] = stack
+		}
+	}
+	return snap
+}
+
+func parseID(stack string) (int, bool) {
+	const prefix = "goroutine "
+	if !strings.HasPrefix(stack, prefix) {
+		return 0, false
+	}
+	fields := strings.SplitN(stack[len(prefix):], " ", 2)
+	id, err := strconv.Atoi(fields[0])
+	return id, err == nil
+}
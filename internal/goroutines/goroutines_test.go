@@ -0,0 +1,41 @@
+package goroutines_test
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/drykit-go/testx/internal/goroutines"
+)
+
+func TestSnapshot(t *testing.T) {
+	t.Run("no leak", func(t *testing.T) {
+		snap := goroutines.Take()
+		done := make(chan struct{})
+		go func() { <-done }()
+		close(done)
+
+		if leaked := snap.Leaked(100 * time.Millisecond); len(leaked) != 0 {
+			t.Errorf("exp no leaked goroutines, got %d:\n%s", len(leaked), leaked)
+		}
+	})
+
+	t.Run("leak", func(t *testing.T) {
+		snap := goroutines.Take()
+		block := make(chan struct{})
+		defer close(block)
+		go leakingFunc(block)
+
+		leaked := snap.Leaked(20 * time.Millisecond)
+		if len(leaked) != 1 {
+			t.Fatalf("exp 1 leaked goroutine, got %d:\n%s", len(leaked), leaked)
+		}
+		if !strings.HasPrefix(leaked[0], "goroutine ") || !strings.Contains(leaked[0], "leakingFunc") {
+			t.Errorf("bad leaked goroutine stack:\n%s", leaked[0])
+		}
+	})
+}
+
+func leakingFunc(block chan struct{}) {
+	<-block
+}
@@ -5,3 +5,7 @@ import "errors"
 // ErrNotAFunc is returned when a func expects a reflect.Func kind
 // and receives a different one.
 var ErrNotAFunc = errors.New("expect a func input")
+
+// ErrBadArgs is returned when a func is provided arguments
+// that do not match its signature.
+var ErrBadArgs = errors.New("invalid arguments")
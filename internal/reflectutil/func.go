@@ -37,9 +37,173 @@ type Func struct {
 }
 
 // Call calls Func's underlying func with given args and returns the results
-// as a slice of empty interfaces.
+// as a slice of empty interfaces. Nil args are replaced with the zero value
+// of the corresponding parameter type.
+//
+// If the func is variadic, the trailing args are passed as its variadic
+// parameter, unless args has exactly one value for each parameter and
+// the last one is a slice of the variadic type (see IsSpreadCall),
+// in which case it is passed as is, like f(args...) would.
 func (f *Func) Call(args []interface{}) []interface{} {
-	return UnwrapValues(f.Value.Call(WrapValues(args)))
+	in := f.wrapArgs(args)
+	if f.IsSpreadCall(args) {
+		return UnwrapValues(f.Value.CallSlice(in))
+	}
+	return UnwrapValues(f.Value.Call(in))
+}
+
+// Caller returns a func calling Func's underlying func with args,
+// discarding the results. The args are converted once beforehand,
+// so the only allocations of a call besides the ones of the underlying
+// func are those of reflect.Value.Call for the results.
+// It panics if args are invalid (see ValidateArgs).
+func (f *Func) Caller(args []interface{}) func() {
+	in := f.wrapArgs(args)
+	if !f.IsVariadic() || f.IsSpreadCall(args) {
+		if f.IsVariadic() {
+			return func() { f.Value.CallSlice(in) }
+		}
+		return func() { f.Value.Call(in) }
+	}
+	// pack the trailing args in a slice once for CallSlice
+	nfixed := f.Value.Type().NumIn() - 1
+	variadic := reflect.MakeSlice(f.Value.Type().In(nfixed), 0, len(in)-nfixed)
+	variadic = reflect.Append(variadic, in[nfixed:]...)
+	packed := append(in[:nfixed:nfixed], variadic)
+	return func() { f.Value.CallSlice(packed) }
+}
+
+// Stub returns a Func whose underlying func has the same parameters
+// as Func's one and does nothing. If withOuts is true, it also has
+// the same return values and returns their zero values, else it returns
+// nothing. The difference of allocations between calls to both stubs
+// via Caller is the allocation overhead of Caller for Func.
+func (f *Func) Stub(withOuts bool) *Func {
+	ftyp := f.Value.Type()
+	in := make([]reflect.Type, ftyp.NumIn())
+	for i := range in {
+		in[i] = ftyp.In(i)
+	}
+	var out []reflect.Type
+	var zeros []reflect.Value
+	if withOuts {
+		out = make([]reflect.Type, ftyp.NumOut())
+		zeros = make([]reflect.Value, ftyp.NumOut())
+		for i := range out {
+			out[i] = ftyp.Out(i)
+			zeros[i] = reflect.Zero(out[i])
+		}
+	}
+	styp := reflect.FuncOf(in, out, ftyp.IsVariadic())
+	stub := reflect.MakeFunc(styp, func([]reflect.Value) []reflect.Value { return zeros })
+	return &Func{
+		FuncSignature: FuncSignature{Name: f.Name + " stub"},
+		Value:         stub,
+	}
+}
+
+// IsVariadic returns true if Func's underlying func is variadic.
+func (f *Func) IsVariadic() bool {
+	return f.Value.Type().IsVariadic()
+}
+
+// IsSpreadCall returns true if Func's underlying func is variadic
+// and the last of args is to be passed as its variadic parameter
+// as a whole, i.e. len(args) equals the number of parameters and the last
+// arg is a slice of the variadic type that is not assignable to the type
+// of its elements.
+func (f *Func) IsSpreadCall(args []interface{}) bool {
+	ftyp := f.Value.Type()
+	nin := ftyp.NumIn()
+	if !ftyp.IsVariadic() || len(args) != nin {
+		return false
+	}
+	last, styp := args[nin-1], ftyp.In(nin-1)
+	return assignable(last, styp) && !assignable(last, styp.Elem())
+}
+
+// ExpandArgs returns args with the last one expanded if IsSpreadCall(args)
+// returns true, else args.
+func (f *Func) ExpandArgs(args []interface{}) []interface{} {
+	if !f.IsSpreadCall(args) {
+		return args
+	}
+	nin := len(args)
+	last := reflect.ValueOf(args[nin-1])
+	expanded := make([]interface{}, 0, nin-1+last.Len())
+	expanded = append(expanded, args[:nin-1]...)
+	for i := 0; i < last.Len(); i++ {
+		expanded = append(expanded, last.Index(i).Interface())
+	}
+	return expanded
+}
+
+// ValidateArgs returns a non-nil error if args cannot be used to call
+// Func's underlying func, either because their number does not match
+// the number of parameters or because an arg is not assignable
+// to the corresponding parameter type.
+func (f *Func) ValidateArgs(args []interface{}) error {
+	ftyp := f.Value.Type()
+	nin := ftyp.NumIn()
+	switch {
+	case ftyp.IsVariadic() && len(args) < nin-1:
+		return fmt.Errorf("%w: exp at least %d args, got %d", ErrBadArgs, nin-1, len(args))
+	case !ftyp.IsVariadic() && len(args) != nin:
+		return fmt.Errorf("%w: exp %d args, got %d", ErrBadArgs, nin, len(args))
+	}
+	spread := f.IsSpreadCall(args)
+	for i, arg := range args {
+		if ptyp := f.paramType(i, spread); !assignable(arg, ptyp) {
+			return fmt.Errorf(
+				"%w: arg %d: exp type %s, got %T (%v)",
+				ErrBadArgs, i, ptyp, arg, arg,
+			)
+		}
+	}
+	return nil
+}
+
+// paramType returns the type of the parameter receiving the ith arg.
+// For variadic funcs, it is the type of the variadic elements for trailing
+// args, unless spread is true.
+func (f *Func) paramType(i int, spread bool) reflect.Type {
+	ftyp := f.Value.Type()
+	nin := ftyp.NumIn()
+	if !ftyp.IsVariadic() || i < nin-1 || spread {
+		return ftyp.In(i)
+	}
+	return ftyp.In(nin - 1).Elem()
+}
+
+// ErrOutPos returns the position of the last return value of Func's
+// underlying func and true if its type is error, else -1 and false.
+func (f *Func) ErrOutPos() (pos int, ok bool) {
+	ftyp := f.Value.Type()
+	nout := ftyp.NumOut()
+	if nout == 0 || ftyp.Out(nout-1) != ErrorType {
+		return -1, false
+	}
+	return nout - 1, true
+}
+
+func (f *Func) wrapArgs(args []interface{}) []reflect.Value {
+	ftyp := f.Value.Type()
+	spread := f.IsSpreadCall(args)
+	wrapped := WrapValues(args)
+	for i, w := range wrapped {
+		if !w.IsValid() && (ftyp.IsVariadic() || i < ftyp.NumIn()) {
+			wrapped[i] = reflect.Zero(f.paramType(i, spread))
+		}
+	}
+	return wrapped
+}
+
+// assignable returns true if v can be assigned to a value of type t.
+func assignable(v interface{}, t reflect.Type) bool {
+	if v == nil {
+		return IsNillable(t.Kind())
+	}
+	return reflect.TypeOf(v).AssignableTo(t)
 }
 
 // NewFunc returns a *Func from the given func input, or a non-nil error
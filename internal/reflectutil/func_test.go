@@ -3,6 +3,7 @@ package reflectutil_test
 import (
 	"errors"
 	"reflect"
+	"strings"
 	"testing"
 
 	"github.com/drykit-go/testx/internal/reflectutil"
@@ -74,6 +75,199 @@ func TestFunc_Call(t *testing.T) {
 	}
 }
 
+func TestFunc_CallNilArgs(t *testing.T) {
+	f, _ := reflectutil.NewFunc(func(err error, b []byte) bool {
+		return err == nil && b == nil
+	})
+	if got := f.Call([]interface{}{nil, nil})[0]; got != true {
+		t.Errorf("exp nil args to be passed as zero values, got %v", got)
+	}
+}
+
+func TestFunc_ValidateArgs(t *testing.T) {
+	f, _ := reflectutil.NewFunc(func(int, string, error) {})
+
+	for _, tc := range []struct {
+		args   []interface{}
+		expErr string
+	}{
+		{args: []interface{}{1, "a", errors.New("")}},
+		{args: []interface{}{1, "a", nil}},
+		{
+			args:   []interface{}{1, "a"},
+			expErr: "invalid arguments: exp 3 args, got 2",
+		},
+		{
+			args:   []interface{}{1, 2, nil},
+			expErr: "invalid arguments: arg 1: exp type string, got int (2)",
+		},
+		{
+			args:   []interface{}{nil, "a", nil},
+			expErr: "invalid arguments: arg 0: exp type int, got <nil> (<nil>)",
+		},
+	} {
+		err := f.ValidateArgs(tc.args)
+		if tc.expErr == "" {
+			if err != nil {
+				t.Errorf("args %v: got unexpected error: %s", tc.args, err)
+			}
+			continue
+		}
+		if !errors.Is(err, reflectutil.ErrBadArgs) || err.Error() != tc.expErr {
+			t.Errorf("args %v: bad error\nexp %s\ngot %v", tc.args, tc.expErr, err)
+		}
+	}
+}
+
+func join(sep string, parts ...string) string {
+	return strings.Join(parts, sep)
+}
+
+func TestFunc_Variadic(t *testing.T) {
+	f, _ := reflectutil.NewFunc(join)
+	if !f.IsVariadic() {
+		t.Error("exp join to be variadic")
+	}
+
+	for _, tc := range []struct {
+		args        []interface{}
+		expOut      string
+		expSpread   bool
+		expExpanded []interface{}
+	}{
+		{
+			args:        []interface{}{","},
+			expOut:      "",
+			expExpanded: []interface{}{","},
+		},
+		{
+			args:        []interface{}{",", "a"},
+			expOut:      "a",
+			expExpanded: []interface{}{",", "a"},
+		},
+		{
+			args:        []interface{}{",", "a", "b", "c"},
+			expOut:      "a,b,c",
+			expExpanded: []interface{}{",", "a", "b", "c"},
+		},
+		{
+			args:        []interface{}{",", []string{"a", "b"}},
+			expOut:      "a,b",
+			expSpread:   true,
+			expExpanded: []interface{}{",", "a", "b"},
+		},
+	} {
+		if err := f.ValidateArgs(tc.args); err != nil {
+			t.Errorf("%v: got unexpected error: %s", tc.args, err)
+		}
+		if got := f.Call(tc.args)[0]; got != tc.expOut {
+			t.Errorf("%v: exp output %q, got %q", tc.args, tc.expOut, got)
+		}
+		if got := f.IsSpreadCall(tc.args); got != tc.expSpread {
+			t.Errorf("%v: exp IsSpreadCall %v, got %v", tc.args, tc.expSpread, got)
+		}
+		if got := f.ExpandArgs(tc.args); !reflect.DeepEqual(got, tc.expExpanded) {
+			t.Errorf("%v: exp ExpandArgs %v, got %v", tc.args, tc.expExpanded, got)
+		}
+	}
+
+	t.Run("interface elements are never spread", func(t *testing.T) {
+		f, _ := reflectutil.NewFunc(func(values ...interface{}) int { return len(values) })
+		args := []interface{}{[]interface{}{1, 2}}
+		if got := f.Call(args)[0]; got != 1 {
+			t.Errorf("exp 1 variadic value, got %v", got)
+		}
+	})
+
+	t.Run("invalid args", func(t *testing.T) {
+		for _, tc := range []struct {
+			args   []interface{}
+			expErr string
+		}{
+			{
+				args:   []interface{}{},
+				expErr: "invalid arguments: exp at least 1 args, got 0",
+			},
+			{
+				args:   []interface{}{",", "a", 1},
+				expErr: "invalid arguments: arg 2: exp type string, got int (1)",
+			},
+			{
+				args:   []interface{}{",", []int{}},
+				expErr: "invalid arguments: arg 1: exp type string, got []int ([])",
+			},
+		} {
+			if err := f.ValidateArgs(tc.args); err == nil || err.Error() != tc.expErr {
+				t.Errorf("%v: bad error\nexp %s\ngot %v", tc.args, tc.expErr, err)
+			}
+		}
+	})
+}
+
+func TestFunc_ErrOutPos(t *testing.T) {
+	for _, tc := range []struct {
+		fn     interface{}
+		expPos int
+		expOK  bool
+	}{
+		{fn: func() {}, expPos: -1, expOK: false},
+		{fn: func() int { return 0 }, expPos: -1, expOK: false},
+		{fn: func() (error, int) { return nil, 0 }, expPos: -1, expOK: false}, //nolint:revive,stylecheck // error not last on purpose
+		{fn: func() error { return nil }, expPos: 0, expOK: true},
+		{fn: func() (int, string, error) { return 0, "", nil }, expPos: 2, expOK: true},
+	} {
+		f, _ := reflectutil.NewFunc(tc.fn)
+		pos, ok := f.ErrOutPos()
+		if pos != tc.expPos || ok != tc.expOK {
+			t.Errorf(
+				"%T: exp (%d, %v), got (%d, %v)",
+				tc.fn, tc.expPos, tc.expOK, pos, ok,
+			)
+		}
+	}
+}
+
+func TestFunc_Caller(t *testing.T) {
+	var got []string
+	record := func(sep string, parts ...string) string {
+		got = append(got, strings.Join(parts, sep))
+		return ""
+	}
+	f, _ := reflectutil.NewFunc(record)
+
+	for _, args := range [][]interface{}{
+		{","},
+		{",", "a", "b"},
+		{",", []string{"a", "b"}},
+	} {
+		call := f.Caller(args)
+		call()
+		call()
+	}
+
+	exp := []string{"", "", "a,b", "a,b", "a,b", "a,b"}
+	if !reflect.DeepEqual(got, exp) {
+		t.Errorf("\nexp %q\ngot %q", exp, got)
+	}
+}
+
+func TestFunc_Stub(t *testing.T) {
+	f, _ := reflectutil.NewFunc(join)
+
+	withOuts := f.Stub(true)
+	if exp, got := reflect.TypeOf(join), withOuts.Value.Type(); got != exp {
+		t.Errorf("exp type %s, got %s", exp, got)
+	}
+	if out := withOuts.Call([]interface{}{",", "a"}); !reflect.DeepEqual(out, []interface{}{""}) {
+		t.Errorf("exp zero values, got %v", out)
+	}
+
+	noOuts := f.Stub(false)
+	if exp, got := reflect.TypeOf(func(string, ...string) {}), noOuts.Value.Type(); got != exp {
+		t.Errorf("exp type %s, got %s", exp, got)
+	}
+}
+
 func TestFuncSignature_Match(t *testing.T) {
 	ftyp := reflect.TypeOf(ValidFunc)
 
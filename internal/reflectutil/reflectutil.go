@@ -1,6 +1,7 @@
 package reflectutil
 
 import (
+	"encoding/json"
 	"fmt"
 	"reflect"
 )
@@ -8,11 +9,113 @@ import (
 // AnyKind is a kind that is interpreted as any kind.
 const AnyKind reflect.Kind = 27
 
+// ErrorType is the reflect.Type of the error interface.
+var ErrorType = reflect.TypeOf((*error)(nil)).Elem()
+
 // IsZero returns true if v is a zero value.
 func IsZero(v interface{}) bool {
 	return reflect.ValueOf(v).IsZero()
 }
 
+// IsNillable returns true if a value of kind k can be nil.
+func IsNillable(k reflect.Kind) bool {
+	switch k {
+	case reflect.Chan, reflect.Func, reflect.Interface,
+		reflect.Map, reflect.Ptr, reflect.Slice, reflect.UnsafePointer:
+		return true
+	default:
+		return false
+	}
+}
+
+// UnmarshalJSONAs unmarshals JSON data into a new value of type typ
+// and returns it as an empty interface.
+func UnmarshalJSONAs(data []byte, typ reflect.Type) (interface{}, error) {
+	ptr := reflect.New(typ)
+	if err := json.Unmarshal(data, ptr.Interface()); err != nil {
+		return nil, err
+	}
+	return ptr.Elem().Interface(), nil
+}
+
+// DeepCopy returns a deep copy of v: the values referenced by pointers,
+// slices, maps and interfaces are copied recursively, as well as
+// exported struct fields. Unexported struct fields, funcs and channels
+// are copied shallowly. Cycles of pointers are preserved.
+func DeepCopy(v interface{}) interface{} {
+	if v == nil {
+		return nil
+	}
+	return deepCopy(reflect.ValueOf(v), map[copiedPtr]reflect.Value{}).Interface()
+}
+
+// copiedPtr identifies a pointer already copied by deepCopy.
+type copiedPtr struct {
+	typ  reflect.Type
+	addr uintptr
+}
+
+func deepCopy(v reflect.Value, copied map[copiedPtr]reflect.Value) reflect.Value {
+	switch v.Kind() {
+	case reflect.Ptr:
+		if v.IsNil() {
+			return v
+		}
+		key := copiedPtr{typ: v.Type(), addr: v.Pointer()}
+		if cp, ok := copied[key]; ok {
+			return cp
+		}
+		cp := reflect.New(v.Type().Elem())
+		copied[key] = cp
+		cp.Elem().Set(deepCopy(v.Elem(), copied))
+		return cp
+	case reflect.Interface:
+		if v.IsNil() {
+			return v
+		}
+		cp := reflect.New(v.Type()).Elem()
+		cp.Set(deepCopy(v.Elem(), copied))
+		return cp
+	case reflect.Slice:
+		if v.IsNil() {
+			return v
+		}
+		cp := reflect.MakeSlice(v.Type(), v.Len(), v.Len())
+		for i := 0; i < v.Len(); i++ {
+			cp.Index(i).Set(deepCopy(v.Index(i), copied))
+		}
+		return cp
+	case reflect.Array:
+		cp := reflect.New(v.Type()).Elem()
+		for i := 0; i < v.Len(); i++ {
+			cp.Index(i).Set(deepCopy(v.Index(i), copied))
+		}
+		return cp
+	case reflect.Map:
+		if v.IsNil() {
+			return v
+		}
+		cp := reflect.MakeMapWithSize(v.Type(), v.Len())
+		iter := v.MapRange()
+		for iter.Next() {
+			cp.SetMapIndex(iter.Key(), deepCopy(iter.Value(), copied))
+		}
+		return cp
+	case reflect.Struct:
+		cp := reflect.New(v.Type()).Elem()
+		cp.Set(v)
+		for i := 0; i < v.NumField(); i++ {
+			if v.Type().Field(i).PkgPath != "" { // unexported
+				continue
+			}
+			cp.Field(i).Set(deepCopy(v.Field(i), copied))
+		}
+		return cp
+	default:
+		return v
+	}
+}
+
 // CallUnwrap calls fn with args and returns the output values
 // as []interface{}.
 func CallUnwrap(fval reflect.Value, args []interface{}) (output []interface{}) {
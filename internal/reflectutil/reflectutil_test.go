@@ -36,6 +36,40 @@ func TestIsZero(t *testing.T) {
 	})
 }
 
+func TestUnmarshalJSONAs(t *testing.T) {
+	type point struct{ X, Y int }
+
+	t.Run("valid data", func(t *testing.T) {
+		for _, tc := range []struct {
+			data string
+			typ  reflect.Type
+			exp  interface{}
+		}{
+			{data: `42`, typ: reflect.TypeOf(0), exp: 42},
+			{data: `42`, typ: reflect.TypeOf(uint8(0)), exp: uint8(42)},
+			{data: `"hi"`, typ: reflect.TypeOf(""), exp: "hi"},
+			{data: `[1,2]`, typ: reflect.TypeOf([]int{}), exp: []int{1, 2}},
+			{data: `{"X":1,"Y":2}`, typ: reflect.TypeOf(point{}), exp: point{1, 2}},
+			{data: `1.5`, typ: reflect.TypeOf((*interface{})(nil)).Elem(), exp: 1.5},
+		} {
+			got, err := reflectutil.UnmarshalJSONAs([]byte(tc.data), tc.typ)
+			if err != nil {
+				t.Errorf("%s: got unexpected error: %s", tc.data, err)
+			}
+			if !reflect.DeepEqual(got, tc.exp) {
+				t.Errorf("%s: exp %#v, got %#v", tc.data, tc.exp, got)
+			}
+		}
+	})
+
+	t.Run("invalid data", func(t *testing.T) {
+		_, err := reflectutil.UnmarshalJSONAs([]byte(`"hi"`), reflect.TypeOf(0))
+		if err == nil {
+			t.Error("exp non-nil error, got nil")
+		}
+	})
+}
+
 func TestCallUnwrap(t *testing.T) {
 	swap := func(x, y float64) (float64, float64) {
 		return y, x
@@ -78,3 +112,46 @@ func TestMustBeOfKind(t *testing.T) {
 		}
 	})
 }
+
+func TestDeepCopy(t *testing.T) {
+	type node struct {
+		Vals []int
+		Next *node
+		tags map[string]int
+	}
+	n := &node{Vals: []int{1, 2}, tags: map[string]int{"a": 1}}
+	n.Next = n
+	in := []interface{}{
+		nil,
+		42,
+		[]int{1, 2},
+		[2][]int{{1}, {2}},
+		map[string][]int{"a": {1}},
+		n,
+		struct{ In interface{} }{In: []string{"a"}},
+	}
+
+	for _, v := range in {
+		cp := reflectutil.DeepCopy(v)
+		if !reflect.DeepEqual(cp, v) {
+			t.Errorf("exp copy to equal %v, got %v", v, cp)
+		}
+	}
+
+	s := []int{1, 2}
+	cp := reflectutil.DeepCopy(s).([]int)
+	cp[0] = -1
+	if s[0] != 1 {
+		t.Errorf("exp slice not to be altered by its copy, got %v", s)
+	}
+
+	ncp := reflectutil.DeepCopy(n).(*node)
+	ncp.Vals[0] = -1
+	ncp.tags["a"] = -1
+	if n.Vals[0] != 1 || ncp.Next != ncp {
+		t.Errorf("exp deep copy with preserved cycle, got %v and %v", n, ncp)
+	}
+	if n.tags["a"] != -1 {
+		t.Errorf("exp unexported fields to be copied shallowly, got %v", n.tags)
+	}
+}
@@ -0,0 +1,73 @@
+package testx
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/drykit-go/testx/check"
+	"github.com/drykit-go/testx/internal/fmtexpl"
+	"github.com/drykit-go/testx/internal/goroutines"
+)
+
+// leakGracePeriod is the maximum duration goroutines started by
+// the tested code are given to terminate before being reported as leaked.
+const leakGracePeriod = 100 * time.Millisecond
+
+// leakDetector detects the goroutines started and not terminated
+// between calls to start and stop, if enabled.
+type leakDetector struct {
+	enabled bool
+	snap    goroutines.Snapshot
+	leaked  []string
+}
+
+func (d *leakDetector) start() {
+	if d.enabled {
+		d.snap = goroutines.Take()
+	}
+}
+
+func (d *leakDetector) stop() {
+	if d.enabled {
+		d.leaked = d.snap.Leaked(leakGracePeriod)
+	}
+}
+
+// check returns a check failing if leaked goroutines were detected.
+func (d *leakDetector) check() baseCheck {
+	return baseCheck{
+		label:   "goroutines",
+		get:     func() gottype { return d.leaked },
+		checker: noLeakChecker(),
+	}
+}
+
+func noLeakChecker() check.ValueChecker {
+	pass := func(got interface{}) bool {
+		leaked, _ := got.([]string)
+		return len(leaked) == 0
+	}
+	expl := func(label string, got interface{}) string {
+		leaked, _ := got.([]string)
+		return fmtexpl.Default(label,
+			"no leaked goroutines",
+			fmt.Sprintf("%d leaked goroutines:\n\n%s", len(leaked), strings.Join(leaked, "\n\n")),
+		)
+	}
+	return check.NewValueChecker(pass, expl)
+}
+
+func leakCheck(t *testing.T) {
+	t.Helper()
+	d := &leakDetector{enabled: true}
+	d.start()
+	t.Cleanup(func() {
+		t.Helper()
+		d.stop()
+		r := baseRunner{}
+		r.addCheck(d.check())
+		r.run(t)
+	})
+}
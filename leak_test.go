@@ -0,0 +1,14 @@
+package testx_test
+
+import (
+	"testing"
+
+	"github.com/drykit-go/testx"
+)
+
+func TestLeakCheck(t *testing.T) {
+	testx.LeakCheck(t)
+	done := make(chan struct{})
+	go func() { close(done) }()
+	<-done
+}
@@ -0,0 +1,202 @@
+package testx
+
+import (
+	"bytes"
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"io"
+	"mime/multipart"
+	"net/http"
+	"net/http/httptest"
+	"net/textproto"
+	"net/url"
+	"sort"
+
+	"github.com/drykit-go/cond"
+)
+
+// RequestFile is a file attached to a multipart request
+// by RequestBuilder.Multipart.
+type RequestFile struct {
+	// Field is the name of the form field.
+	Field string
+	// Name is the name of the file.
+	Name string
+	// Content is the content of the file.
+	Content []byte
+	// ContentType is the content type of the file.
+	// Default is "application/octet-stream".
+	ContentType string
+}
+
+var _ RequestBuilder = (*requestBuilder)(nil)
+
+type requestBuilder struct {
+	method, target string
+
+	query   url.Values
+	header  http.Header
+	cookies []*http.Cookie
+	ctxVals []ctxVal
+
+	body        []byte
+	contentType string
+	// err is the first error that occurred while setting the body.
+	err error
+}
+
+type ctxVal struct {
+	key, val interface{}
+}
+
+func (b *requestBuilder) Query(key string, values ...string) RequestBuilder {
+	for _, v := range values {
+		b.query.Add(key, v)
+	}
+	return b
+}
+
+func (b *requestBuilder) Header(key string, values ...string) RequestBuilder {
+	for _, v := range values {
+		b.header.Add(key, v)
+	}
+	return b
+}
+
+func (b *requestBuilder) Cookie(cookies ...*http.Cookie) RequestBuilder {
+	b.cookies = append(b.cookies, cookies...)
+	return b
+}
+
+func (b *requestBuilder) ContextValue(key, val interface{}) RequestBuilder {
+	b.ctxVals = append(b.ctxVals, ctxVal{key: key, val: val})
+	return b
+}
+
+func (b *requestBuilder) BasicAuth(username, password string) RequestBuilder {
+	credentials := base64.StdEncoding.EncodeToString([]byte(username + ":" + password))
+	b.header.Set("Authorization", "Basic "+credentials)
+	return b
+}
+
+func (b *requestBuilder) BearerAuth(token string) RequestBuilder {
+	b.header.Set("Authorization", "Bearer "+token)
+	return b
+}
+
+func (b *requestBuilder) Body(body []byte, contentType string) RequestBuilder {
+	b.body, b.contentType = body, contentType
+	return b
+}
+
+func (b *requestBuilder) JSON(v interface{}) RequestBuilder {
+	body, err := json.Marshal(v)
+	if err != nil {
+		b.setErr(fmt.Errorf("JSON: %v", err))
+	}
+	return b.Body(body, "application/json")
+}
+
+func (b *requestBuilder) Form(values url.Values) RequestBuilder {
+	return b.Body([]byte(values.Encode()), "application/x-www-form-urlencoded")
+}
+
+func (b *requestBuilder) Multipart(fields url.Values, files ...RequestFile) RequestBuilder {
+	var buf bytes.Buffer
+	w := multipart.NewWriter(&buf)
+	if err := writeMultipart(w, fields, files); err != nil {
+		b.setErr(fmt.Errorf("Multipart: %v", err))
+	}
+	return b.Body(buf.Bytes(), w.FormDataContentType())
+}
+
+func (b *requestBuilder) Build() *http.Request {
+	cond.PanicOnErr(b.err)
+
+	u, err := url.Parse(b.target)
+	cond.PanicOnErr(b.wrapErr(err))
+	if len(b.query) != 0 {
+		q := u.Query()
+		for k, values := range b.query {
+			q[k] = append(q[k], values...)
+		}
+		u.RawQuery = q.Encode()
+	}
+
+	var body io.Reader
+	if b.body != nil {
+		body = bytes.NewReader(b.body)
+	}
+	rq := httptest.NewRequest(b.method, u.String(), body)
+	for k, values := range b.header {
+		rq.Header[k] = append([]string(nil), values...)
+	}
+	if b.contentType != "" {
+		rq.Header.Set("Content-Type", b.contentType)
+	}
+	for _, c := range b.cookies {
+		rq.AddCookie(c)
+	}
+
+	ctx := rq.Context()
+	for _, cv := range b.ctxVals {
+		ctx = context.WithValue(ctx, cv.key, cv.val)
+	}
+	return rq.WithContext(ctx)
+}
+
+func (b *requestBuilder) setErr(err error) {
+	if b.err == nil {
+		b.err = b.wrapErr(err)
+	}
+}
+
+func (b *requestBuilder) wrapErr(err error) error {
+	if err == nil {
+		return nil
+	}
+	return fmt.Errorf("%w: %s %s: %v", errRequestBuilder, b.method, b.target, err)
+}
+
+// writeMultipart writes the fields in keys order, then the files,
+// and closes w.
+func writeMultipart(w *multipart.Writer, fields url.Values, files []RequestFile) error {
+	keys := make([]string, 0, len(fields))
+	for k := range fields {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	for _, k := range keys {
+		for _, v := range fields[k] {
+			if err := w.WriteField(k, v); err != nil {
+				return err
+			}
+		}
+	}
+
+	for _, f := range files {
+		contentType := cond.String(f.ContentType, "application/octet-stream", f.ContentType != "")
+		h := textproto.MIMEHeader{}
+		h.Set("Content-Disposition", fmt.Sprintf(`form-data; name=%q; filename=%q`, f.Field, f.Name))
+		h.Set("Content-Type", contentType)
+		part, err := w.CreatePart(h)
+		if err != nil {
+			return err
+		}
+		if _, err := part.Write(f.Content); err != nil {
+			return err
+		}
+	}
+	return w.Close()
+}
+
+func newRequestBuilder(method, target string) RequestBuilder {
+	return &requestBuilder{
+		method: method,
+		target: target,
+		query:  url.Values{},
+		header: http.Header{},
+	}
+}
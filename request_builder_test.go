@@ -0,0 +1,115 @@
+package testx_test
+
+import (
+	"io"
+	"net/http"
+	"net/url"
+	"testing"
+
+	"github.com/drykit-go/testx"
+	"github.com/drykit-go/testx/check"
+	"github.com/drykit-go/testx/internal/testutil"
+)
+
+func TestNewRequest(t *testing.T) {
+	t.Run("url, header, cookies, auth and context", func(t *testing.T) {
+		rq := testx.NewRequest("DELETE", "/movies/42?soft=true").
+			Query("reason", "duplicate", "typo").
+			Header("X-Trace-Id", "abc").
+			Cookie(&http.Cookie{Name: "session", Value: "s3cr3t"}).
+			BasicAuth("admin", "p4ss").
+			ContextValue("userID", 42).
+			Build()
+
+		testx.HTTPHandlerFunc(nil).WithRequest(rq).
+			Request(
+				check.HTTPRequest.Method(check.String.Is("DELETE")),
+				check.HTTPRequest.Path(check.String.Is("/movies/42")),
+				check.HTTPRequest.Query("soft", check.String.Is("true")),
+				check.HTTPRequest.Header(check.HTTPHeader.CheckValue("X-Trace-Id", check.String.Is("abc"))),
+				check.HTTPRequest.Context(check.Context.Value("userID", check.Value.Is(42))),
+			).
+			Run(t)
+
+		if reasons := rq.URL.Query()["reason"]; len(reasons) != 2 || reasons[1] != "typo" {
+			t.Errorf("bad query values: %v", reasons)
+		}
+		if c, err := rq.Cookie("session"); err != nil || c.Value != "s3cr3t" {
+			t.Errorf("bad cookie: %v, %v", c, err)
+		}
+		if user, pass, ok := rq.BasicAuth(); !ok || user != "admin" || pass != "p4ss" {
+			t.Errorf("bad basic auth: %s:%s", user, pass)
+		}
+
+		rq = testx.NewRequest("GET", "/").BearerAuth("t0k3n").Build()
+		if auth := rq.Header.Get("Authorization"); auth != "Bearer t0k3n" {
+			t.Errorf("bad bearer auth: %s", auth)
+		}
+	})
+
+	t.Run("json body", func(t *testing.T) {
+		builder := testx.NewRequest("POST", "/movies").JSON(map[string]interface{}{"id": 42})
+		for i := 0; i < 2; i++ { // each request has its own body
+			testx.HTTPHandlerFunc(nil).WithRequest(builder.Build()).
+				Request(
+					check.HTTPRequest.Header(check.HTTPHeader.CheckValue("Content-Type", check.String.Is("application/json"))),
+					check.HTTPRequest.Body(check.Bytes.Is([]byte(`{"id":42}`))),
+					check.HTTPRequest.ContentLength(check.Int.Is(9)),
+				).
+				Run(t)
+		}
+	})
+
+	t.Run("form body", func(t *testing.T) {
+		rq := testx.NewRequest("POST", "/login").
+			Form(url.Values{"user": {"admin"}, "remember": {"1"}}).
+			Build()
+		if err := rq.ParseForm(); err != nil {
+			t.Fatal(err)
+		}
+		if user, remember := rq.PostFormValue("user"), rq.PostFormValue("remember"); user != "admin" || remember != "1" {
+			t.Errorf("bad form values: user %q, remember %q", user, remember)
+		}
+	})
+
+	t.Run("multipart body", func(t *testing.T) {
+		rq := testx.NewRequest("POST", "/upload").
+			Multipart(
+				url.Values{"title": {"Inception"}},
+				testx.RequestFile{Field: "poster", Name: "poster.png", Content: []byte("png"), ContentType: "image/png"},
+				testx.RequestFile{Field: "script", Name: "script.txt", Content: []byte("txt")},
+			).
+			Build()
+		if err := rq.ParseMultipartForm(1 << 20); err != nil {
+			t.Fatal(err)
+		}
+		if title := rq.FormValue("title"); title != "Inception" {
+			t.Errorf("bad field value: %q", title)
+		}
+		for field, exp := range map[string]struct{ name, contentType, content string }{
+			"poster": {"poster.png", "image/png", "png"},
+			"script": {"script.txt", "application/octet-stream", "txt"},
+		} {
+			f, h, err := rq.FormFile(field)
+			if err != nil {
+				t.Fatal(err)
+			}
+			content, _ := io.ReadAll(f)
+			if h.Filename != exp.name || h.Header.Get("Content-Type") != exp.contentType || string(content) != exp.content {
+				t.Errorf("bad file %s: %s %s %q", field, h.Filename, h.Header.Get("Content-Type"), content)
+			}
+		}
+	})
+
+	t.Run("bad inputs", func(t *testing.T) {
+		for _, build := range []func(){
+			func() { testx.NewRequest("POST", "/").JSON(make(chan int)).Build() },
+			func() { testx.NewRequest("GET", "/%zz").Build() },
+		} {
+			func() {
+				defer testutil.AssertPanic(t)
+				build()
+			}()
+		}
+	})
+}
@@ -26,13 +26,7 @@ func (r *baseRunner) addCheck(bc baseCheck) {
 	r.checks = append(r.checks, bc)
 }
 
-func (r *baseRunner) addChecks(label string, get getfunc, checkers []check.ValueChecker) {
-	for _, c := range checkers {
-		r.addCheck(baseCheck{label: label, get: get, checker: c})
-	}
-}
-
-func (r *baseRunner) run(t *testing.T) {
+func (r *baseRunner) run(t testing.TB) {
 	t.Helper()
 	for _, current := range r.checks {
 		got := current.get()
@@ -72,7 +66,7 @@ func (r *baseRunner) explainCheck(bc baseCheck, got interface{}, passed bool) st
 	return bc.checker.Explain(label, got)
 }
 
-func (r *baseRunner) fail(t *testing.T, msg string) {
+func (r *baseRunner) fail(t testing.TB, msg string) {
 	t.Helper()
 	t.Error(msg)
 }
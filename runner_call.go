@@ -0,0 +1,356 @@
+package testx
+
+import (
+	"fmt"
+	"runtime/debug"
+	"testing"
+	"time"
+
+	"github.com/drykit-go/cond"
+
+	"github.com/drykit-go/testx/check"
+	"github.com/drykit-go/testx/checkconv"
+	"github.com/drykit-go/testx/internal/fmtexpl"
+	"github.com/drykit-go/testx/internal/reflectutil"
+)
+
+var _ CallRunner = (*callRunner)(nil)
+
+type callRunner struct {
+	baseRunner
+
+	rfunc *reflectutil.Func
+	args  Args
+
+	// outChecks and allocsChecks are run if the func returned,
+	// panicCheckers if it panicked as expected, durationChecks in any case.
+	outChecks      []baseCheck
+	allocsChecks   []baseCheck
+	panicCheckers  []check.ValueChecker
+	durationChecks []baseCheck
+	expPanic       bool
+
+	leaks leakDetector
+	// measureAllocs is true if allocations checks were added.
+	measureAllocs bool
+	// allocsPanic is the panic recovered while measuring allocations.
+	allocsPanic interface{}
+	sampler     sampler
+
+	got callResults
+	// stack is the stack trace of the recovered panic.
+	stack []byte
+}
+
+func (r *callRunner) Out(pos int, checkers ...check.ValueChecker) CallRunner {
+	if nout := r.rfunc.Value.Type().NumOut(); pos < 0 || pos >= nout {
+		panic(errCallRunnerOutPos(r.rfunc.Name, pos, nout))
+	}
+	r.addOutChecks(pos, fmtexpl.TableCaseOutLabel(r.label(), pos), checkers)
+	return r
+}
+
+func (r *callRunner) Err(checkers ...check.ValueChecker) CallRunner {
+	perr, ok := r.rfunc.ErrOutPos()
+	if !ok {
+		panic(errCallRunnerNoErrOut(r.rfunc.Name))
+	}
+	r.addOutChecks(perr, r.label()+" error", checkers)
+	return r
+}
+
+func (r *callRunner) Panics(checkers ...check.ValueChecker) CallRunner {
+	r.expPanic = true
+	r.panicCheckers = append(r.panicCheckers, checkers...)
+	return r
+}
+
+func (r *callRunner) NotPanics() CallRunner {
+	r.expPanic = false
+	r.outChecks = append(r.outChecks, baseCheck{
+		label:   r.label(),
+		get:     func() gottype { return r.got.recovered },
+		checker: r.noPanicChecker(),
+	})
+	return r
+}
+
+func (r *callRunner) Duration(checkers ...check.DurationChecker) CallRunner {
+	for _, c := range checkers {
+		r.durationChecks = append(r.durationChecks, baseCheck{
+			label:   r.label() + " duration",
+			get:     func() gottype { return r.got.duration },
+			checker: checkconv.FromDuration(c),
+		})
+	}
+	return r
+}
+
+func (r *callRunner) DurationStats(checkers ...check.DurationStatsChecker) CallRunner {
+	for _, c := range checkers {
+		r.durationChecks = append(r.durationChecks, baseCheck{
+			label:   r.label() + " duration samples",
+			get:     func() gottype { return r.got.durations },
+			checker: checkconv.FromDurationStats(c),
+		})
+	}
+	return r
+}
+
+func (r *callRunner) Repeat(n int) CallRunner {
+	r.sampler.setRepeat(n)
+	return r
+}
+
+func (r *callRunner) Warmup(k int) CallRunner {
+	r.sampler.setWarmup(k)
+	return r
+}
+
+func (r *callRunner) Allocs(checkers ...check.IntChecker) CallRunner {
+	r.addAllocsChecks(r.label()+" allocs", func() gottype { return r.got.allocs }, checkers)
+	return r
+}
+
+func (r *callRunner) AllocBytes(checkers ...check.IntChecker) CallRunner {
+	r.addAllocsChecks(r.label()+" allocated bytes", func() gottype { return r.got.allocBytes }, checkers)
+	return r
+}
+
+func (r *callRunner) addAllocsChecks(label string, get getfunc, checkers []check.IntChecker) {
+	r.measureAllocs = true
+	for _, c := range checkers {
+		r.allocsChecks = append(r.allocsChecks, baseCheck{
+			label:   label,
+			get:     get,
+			checker: checkconv.FromInt(c),
+		})
+	}
+}
+
+func (r *callRunner) NoGoroutineLeaks() CallRunner {
+	r.leaks.enabled = true
+	return r
+}
+
+func (r *callRunner) Run(t *testing.T) {
+	t.Helper()
+	r.setResults()
+	r.run(t)
+}
+
+func (r *callRunner) DryRun() CallResulter {
+	r.setResults()
+	results := r.got
+	results.baseResults = r.dryRun()
+	return results
+}
+
+func (r *callRunner) addOutChecks(pos int, label string, checkers []check.ValueChecker) {
+	for _, c := range checkers {
+		r.outChecks = append(r.outChecks, baseCheck{
+			label:   label,
+			get:     func() gottype { return r.got.outs[pos] },
+			checker: c,
+		})
+	}
+}
+
+// setResults calls the tested func and sets the checks to be run
+// according to the outcome.
+func (r *callRunner) setResults() {
+	r.got.outs, r.got.recovered, r.stack, r.allocsPanic = nil, nil, nil, nil
+	r.leaks.start()
+	r.got.durations = r.sampler.run(r.safeCall, r.recoveredCall())
+	r.got.duration = r.got.durations[0]
+	r.leaks.stop()
+	if r.measureAllocs && r.got.recovered == nil {
+		r.got.allocs, r.got.allocBytes, r.allocsPanic = r.allocsPerCall()
+	}
+	r.checks = append(r.outcomeChecks(), r.durationChecks...)
+	if r.leaks.enabled {
+		r.checks = append(r.checks, r.leaks.check())
+	}
+}
+
+// allocsPerCall returns the average number of allocations and of bytes
+// allocated by a call to the tested func, excluding the overhead
+// of calling it via reflection. The func is called more than 200 extra
+// times with the same args, repeating its side effects. A panic in any
+// of these calls is recovered and returned.
+func (r *callRunner) allocsPerCall() (allocs, bytes int, recovered interface{}) {
+	defer func() { recovered = recover() }()
+	callAllocs, callBytes := allocsPerRun(allocRuns, r.rfunc.Caller(r.args), func() {})
+	// the overhead of reflect.Value.Call for the results of the tested func
+	// is the difference between 2 stubs that only differ by their results
+	overAllocs, overBytes := allocsPerRun(allocRuns,
+		r.rfunc.Stub(true).Caller(r.args),
+		r.rfunc.Stub(false).Caller(r.args),
+	)
+	return max0(callAllocs - overAllocs), max0(callBytes - overBytes), nil
+}
+
+// safeCall calls the tested func and recovers any panic.
+func (r *callRunner) safeCall() {
+	defer r.recoverPanic()
+	r.got.outs = r.rfunc.Call(r.args)
+}
+
+// recoveredCall returns a func that calls the tested func
+// and discards its results, for repeated calls.
+func (r *callRunner) recoveredCall() func() {
+	call := r.rfunc.Caller(r.args)
+	return func() {
+		defer r.recoverPanic()
+		call()
+	}
+}
+
+// recoverPanic recovers a panic of the tested func. The first panic
+// of the sampled calls is reported as the panic of the run.
+func (r *callRunner) recoverPanic() {
+	if recovered := recover(); recovered != nil && r.got.recovered == nil {
+		r.got.recovered, r.stack = recovered, debug.Stack()
+	}
+}
+
+// outcomeChecks returns the checks on the return values if the func
+// returned as expected, the panic checks if it panicked as expected,
+// or a single failing check otherwise.
+func (r *callRunner) outcomeChecks() []baseCheck {
+	getRecovered := func() gottype { return r.got.recovered }
+	panicked := r.got.recovered != nil
+
+	if !r.expPanic {
+		if !panicked {
+			return append(append([]baseCheck{}, r.outChecks...), r.allocsOutcomeChecks()...)
+		}
+		return []baseCheck{{
+			label:   r.label(),
+			get:     getRecovered,
+			checker: r.noPanicChecker(),
+		}}
+	}
+
+	checks := []baseCheck{{
+		label:   r.label(),
+		get:     func() gottype { return r.got.outs },
+		checker: r.panicChecker(),
+	}}
+	if !panicked {
+		return checks
+	}
+	for _, checker := range r.panicCheckers {
+		checks = append(checks, baseCheck{
+			label:   fmtexpl.TableCasePanicLabel(r.label()),
+			get:     getRecovered,
+			checker: checker,
+		})
+	}
+	return checks
+}
+
+// allocsOutcomeChecks returns the checks on the allocations, or a single
+// failing check if the func panicked during the calls measuring them.
+func (r *callRunner) allocsOutcomeChecks() []baseCheck {
+	if r.allocsPanic == nil {
+		return r.allocsChecks
+	}
+	return []baseCheck{{
+		label:   r.label() + " allocs",
+		get:     func() gottype { return r.allocsPanic },
+		checker: allocsPanicChecker(),
+	}}
+}
+
+func allocsPanicChecker() check.ValueChecker {
+	pass := func(got interface{}) bool { return got == nil }
+	expl := func(label string, got interface{}) string {
+		return fmtexpl.Default(label,
+			"no panic in the extra calls measuring allocations",
+			fmt.Sprintf("panic: %v", got),
+		)
+	}
+	return check.NewValueChecker(pass, expl)
+}
+
+func (r *callRunner) noPanicChecker() check.ValueChecker {
+	pass := func(got interface{}) bool { return got == nil }
+	expl := func(label string, got interface{}) string {
+		return fmtexpl.Default(label,
+			"no panic",
+			fmt.Sprintf("panic: %v\n\n%s", got, r.stack),
+		)
+	}
+	return check.NewValueChecker(pass, expl)
+}
+
+func (r *callRunner) panicChecker() check.ValueChecker {
+	pass := func(interface{}) bool { return r.got.recovered != nil }
+	expl := func(label string, got interface{}) string {
+		return fmtexpl.Default(label,
+			"panic",
+			fmt.Sprintf("return values %v", got),
+		)
+	}
+	return check.NewValueChecker(pass, expl)
+}
+
+// label returns the label of the call to be printed on failure.
+func (r *callRunner) label() string {
+	return fmtexpl.CallLabel(r.rfunc.Name, Args(r.rfunc.ExpandArgs(r.args)))
+}
+
+func (r *callRunner) setCall(fn interface{}, args Args) error {
+	rfunc, err := reflectutil.NewFunc(fn)
+	if err != nil {
+		return fmt.Errorf("Call(func): %w: %v", errCallRunnerFunc, err)
+	}
+	if err := rfunc.ValidateArgs(args); err != nil {
+		return fmt.Errorf("Call(%s): %w: %v", rfunc.Name, errCallRunnerFunc, err)
+	}
+	r.rfunc, r.args = rfunc, args
+	return nil
+}
+
+func newCallRunner(fn interface{}, args ...interface{}) CallRunner {
+	r := &callRunner{}
+	cond.PanicOnErr(r.setCall(fn, args))
+	return r
+}
+
+type callResults struct {
+	baseResults
+	outs       []interface{}
+	recovered  interface{}
+	duration   time.Duration
+	durations  []time.Duration
+	allocs     int
+	allocBytes int
+}
+
+var _ CallResulter = (*callResults)(nil)
+
+func (res callResults) Outs() []interface{} {
+	return res.outs
+}
+
+func (res callResults) Panic() interface{} {
+	return res.recovered
+}
+
+func (res callResults) Duration() time.Duration {
+	return res.duration
+}
+
+func (res callResults) Durations() []time.Duration {
+	return res.durations
+}
+
+func (res callResults) Allocs() int {
+	return res.allocs
+}
+
+func (res callResults) AllocBytes() int {
+	return res.allocBytes
+}
@@ -0,0 +1,238 @@
+package testx_test
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/drykit-go/testx"
+	"github.com/drykit-go/testx/check"
+	"github.com/drykit-go/testx/internal/testutil"
+)
+
+func TestCallRunner(t *testing.T) {
+	t.Run("should pass", func(t *testing.T) {
+		res := testx.Call(parseDigits, "42").
+			Out(0, check.Value.Is(42)).
+			Out(1, check.Value.Is(2)).
+			Err(check.Value.Is(nil)).
+			NotPanics().
+			Duration(check.Duration.Under(time.Second)).
+			DryRun()
+
+		exp := baseResults{
+			passed:  true,
+			failed:  false,
+			nPassed: 5,
+			nFailed: 0,
+			nChecks: 5,
+			checks: []testx.CheckResult{
+				{Passed: true, Reason: ""},
+				{Passed: true, Reason: ""},
+				{Passed: true, Reason: ""},
+				{Passed: true, Reason: ""},
+				{Passed: true, Reason: ""},
+			},
+		}
+
+		assertEqualBaseResults(t, res, exp)
+		if outs := res.Outs(); len(outs) != 3 || outs[0] != 42 {
+			t.Errorf("bad outs: %v", outs)
+		}
+		if res.Panic() != nil || res.Duration() <= 0 {
+			t.Errorf("bad results: panic %v, duration %v", res.Panic(), res.Duration())
+		}
+	})
+
+	t.Run("should fail", func(t *testing.T) {
+		res := testx.Call(parseDigits, "4x").
+			Out(0, check.Value.Is(42)).
+			Err(check.Value.Is(nil)).
+			DryRun()
+
+		exp := baseResults{
+			passed:  false,
+			failed:  true,
+			nPassed: 0,
+			nFailed: 2,
+			nChecks: 2,
+			checks: []testx.CheckResult{
+				{Passed: false, Reason: "testx_test.parseDigits(\"4x\") out[0]:\nexp 42\ngot 4"},
+				{Passed: false, Reason: "testx_test.parseDigits(\"4x\") error:\nexp <nil>\ngot not a digit"},
+			},
+		}
+
+		assertEqualBaseResults(t, res, exp)
+	})
+
+	t.Run("expected panic", func(t *testing.T) {
+		res := testx.Call(mustParseDigits, "4x").
+			Out(0, check.Value.Is(42)).
+			Panics(check.Value.Is(errNotDigit)).
+			DryRun()
+
+		if !res.Passed() || res.NChecks() != 2 || res.Panic() != errNotDigit {
+			t.Errorf("bad results: %v", res.Checks())
+		}
+
+		res = testx.Call(mustParseDigits, "42").Panics().DryRun()
+		expReason := "testx_test.mustParseDigits(\"42\"):\nexp panic\ngot return values [42 2 <nil>]"
+		if res.Passed() || res.Checks()[0].Reason != expReason {
+			t.Errorf("bad results\nexp %q\ngot %v", expReason, res.Checks())
+		}
+	})
+
+	t.Run("unexpected panic", func(t *testing.T) {
+		res := testx.Call(mustParseDigits, "4x").
+			Out(0, check.Value.Is(4)).
+			Duration(check.Duration.Under(time.Second)).
+			DryRun()
+
+		if res.Passed() || res.NChecks() != 2 || res.NFailed() != 1 {
+			t.Errorf("bad results: %v", res.Checks())
+		}
+		expPrefix := "testx_test.mustParseDigits(\"4x\"):\nexp no panic\ngot panic: not a digit"
+		if reason := res.Checks()[0].Reason; !strings.HasPrefix(reason, expPrefix) {
+			t.Errorf("bad reason\nexp prefix %q\ngot %q", expPrefix, reason)
+		}
+	})
+
+	t.Run("variadic func", func(t *testing.T) {
+		testx.Call(join, "-", "a", "b").Out(0, check.Value.Is("a-b")).Run(t)
+		testx.Call(join, "-", []string{"a", "b"}).Out(0, check.Value.Is("a-b")).Run(t)
+	})
+
+	t.Run("goroutine leaks", func(t *testing.T) {
+		block := make(chan struct{})
+		defer close(block)
+		spawn := func(leak bool) {
+			go func() {
+				if leak {
+					<-block
+				}
+			}()
+		}
+
+		if res := testx.Call(spawn, false).NoGoroutineLeaks().DryRun(); !res.Passed() {
+			t.Errorf("exp no leaks, got %v", res.Checks())
+		}
+
+		res := testx.Call(spawn, true).NoGoroutineLeaks().DryRun()
+		if res.Passed() || res.NChecks() != 1 {
+			t.Fatalf("exp 1 failed check, got %v", res.Checks())
+		}
+		reason := res.Checks()[0].Reason
+		expPrefix := "goroutines:\nexp no leaked goroutines\ngot 1 leaked goroutines:\n\ngoroutine "
+		if !strings.HasPrefix(reason, expPrefix) || !strings.Contains(reason, "TestCallRunner") {
+			t.Errorf("bad reason\nexp prefix %q\ngot %q", expPrefix, reason)
+		}
+	})
+
+	t.Run("allocations", func(t *testing.T) {
+		testx.Call(clamp, 5, 0, 10).
+			Allocs(check.Int.Is(0)).
+			AllocBytes(check.Int.Is(0)).
+			Run(t)
+
+		res := testx.Call(makeBytes, 1024).
+			Allocs(check.Int.Is(0)).                   // fail
+			AllocBytes(check.Int.InRange(1000, 2048)). // approximate measure
+			DryRun()
+
+		if res.Allocs() != 1 || res.AllocBytes() < 1000 {
+			t.Errorf("exp 1 alloc of ~1024 bytes, got %d allocs of %d bytes", res.Allocs(), res.AllocBytes())
+		}
+		expReason := "testx_test.makeBytes(1024) allocs:\nexp 0\ngot 1"
+		if res.NFailed() != 1 || res.Checks()[0].Reason != expReason {
+			t.Errorf("bad checks\nexp %q\ngot %v", expReason, res.Checks())
+		}
+
+		var calls int
+		panicAfterFirstCall := func() int {
+			if calls++; calls > 1 {
+				panic("called again")
+			}
+			return calls
+		}
+		res = testx.Call(panicAfterFirstCall).
+			Out(0, check.Value.Is(1)).
+			Allocs(check.Int.Is(0)).
+			AllocBytes(check.Int.Is(0)).
+			DryRun()
+		expSuffix := " allocs:\nexp no panic in the extra calls measuring allocations\ngot panic: called again"
+		if res.NPassed() != 1 || res.NFailed() != 1 || !strings.HasSuffix(res.Checks()[1].Reason, expSuffix) {
+			t.Errorf("bad checks\nexp suffix %q\ngot %v", expSuffix, res.Checks())
+		}
+	})
+
+	t.Run("duration samples", func(t *testing.T) {
+		var calls int
+		sleep := func(d time.Duration) {
+			calls++
+			time.Sleep(d)
+		}
+
+		res := testx.Call(sleep, time.Millisecond).
+			Warmup(1).
+			Repeat(5).
+			DurationStats(
+				check.DurationStats.Mean(check.Duration.Over(time.Millisecond)),
+				check.DurationStats.P99(check.Duration.Over(time.Millisecond)),
+				check.DurationStats.StdDev(check.Duration.Under(time.Second)),
+			).
+			DryRun()
+
+		if !res.Passed() || calls != 6 || len(res.Durations()) != 5 {
+			t.Errorf("exp 6 calls and 5 samples passing checks, got %d calls, %v samples, %v",
+				calls, res.Durations(), res.Checks())
+		}
+
+		res = testx.Call(mustParseDigits, "4x").
+			Repeat(3).
+			Panics().
+			DurationStats(check.DurationStats.Max(check.Duration.Under(time.Second))).
+			DryRun()
+		if !res.Passed() || len(res.Durations()) != 3 {
+			t.Errorf("exp repeated panicking calls to pass, got %v samples, %v", res.Durations(), res.Checks())
+		}
+
+		for _, sample := range []func(testx.CallRunner) testx.CallRunner{
+			func(r testx.CallRunner) testx.CallRunner { return r.Repeat(3) },
+			func(r testx.CallRunner) testx.CallRunner { return r.Warmup(1) },
+		} {
+			calls = 0
+			panicOnSecondCall := func() int {
+				if calls++; calls == 2 {
+					panic("second call")
+				}
+				return calls
+			}
+			res = sample(testx.Call(panicOnSecondCall)).DryRun()
+			if res.Passed() || res.Panic() != "second call" {
+				t.Errorf("exp panic in a sampled call to fail, got panic %v, %v", res.Panic(), res.Checks())
+			}
+		}
+	})
+
+	t.Run("bad inputs", func(t *testing.T) {
+		for _, newRunner := range []func(){
+			func() { testx.Call(double, 1).Repeat(0) },
+			func() { testx.Call(double, 1).Warmup(-1) },
+			func() { testx.Call(42) },
+			func() { testx.Call(parseDigits) },
+			func() { testx.Call(parseDigits, 42) },
+			func() { testx.Call(parseDigits, "42").Out(3) },
+			func() { testx.Call(double, 1).Err() },
+		} {
+			func() {
+				defer testutil.AssertPanic(t)
+				newRunner()
+			}()
+		}
+	})
+}
+
+// makeBytes returns a new slice of n bytes.
+func makeBytes(n int) []byte {
+	return make([]byte, n)
+}
@@ -0,0 +1,260 @@
+package testx
+
+import (
+	"fmt"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/drykit-go/cond"
+
+	"github.com/drykit-go/testx/check"
+	"github.com/drykit-go/testx/internal/fmtexpl"
+)
+
+// defaultChanTimeout is the maximum duration ChanRunner.ReceivesInOrder
+// waits for the expected values.
+const defaultChanTimeout = time.Second
+
+var _ ChanRunner = (*chanRunner)(nil)
+
+type chanRunner struct {
+	baseRunner
+
+	ch reflect.Value
+	// steps are run in order on the channel, each one returning
+	// a check on its outcome.
+	steps []func() baseCheck
+	// eachCheckers are run on every received value after the steps.
+	eachCheckers []check.ValueChecker
+
+	got chanResults
+}
+
+func (r *chanRunner) Receives(n int, within time.Duration) ChanRunner {
+	r.steps = append(r.steps, func() baseCheck {
+		received := r.receiveN(n, within)
+		closed := r.got.closed
+		pass := func(interface{}) bool { return len(received) == n }
+		expl := func(label string, got interface{}) string {
+			return fmtexpl.Default(label,
+				fmt.Sprintf("to receive %d values within %v", n, within),
+				explainReceived(received, closed),
+			)
+		}
+		return r.newCheck(received, check.NewValueChecker(pass, expl))
+	})
+	return r
+}
+
+func (r *chanRunner) ReceivesInOrder(values ...interface{}) ChanRunner {
+	r.steps = append(r.steps, func() baseCheck {
+		received := r.receiveN(len(values), defaultChanTimeout)
+		closed := r.got.closed
+		pass := func(interface{}) bool {
+			if len(received) != len(values) {
+				return false
+			}
+			for i, v := range values {
+				if !check.Value.Is(v).Pass(received[i]) {
+					return false
+				}
+			}
+			return true
+		}
+		expl := func(label string, got interface{}) string {
+			return fmtexpl.Default(label,
+				fmt.Sprintf("to receive %v in order", values),
+				explainReceived(received, closed),
+			)
+		}
+		return r.newCheck(received, check.NewValueChecker(pass, expl))
+	})
+	return r
+}
+
+func (r *chanRunner) EachReceived(checkers ...check.ValueChecker) ChanRunner {
+	r.eachCheckers = append(r.eachCheckers, checkers...)
+	return r
+}
+
+func (r *chanRunner) Closes(within time.Duration) ChanRunner {
+	r.steps = append(r.steps, func() baseCheck {
+		var received []interface{}
+		deadline := time.Now().Add(within)
+		for !r.got.closed {
+			v, ok := r.receive(time.Until(deadline))
+			if !ok {
+				break
+			}
+			received = append(received, v)
+		}
+		closed := r.got.closed
+		pass := func(interface{}) bool { return closed }
+		expl := func(label string, got interface{}) string {
+			return fmtexpl.Default(label,
+				fmt.Sprintf("to be closed within %v", within),
+				fmt.Sprintf("not closed, %s", explainReceived(received, closed)),
+			)
+		}
+		return r.newCheck(received, check.NewValueChecker(pass, expl))
+	})
+	return r
+}
+
+func (r *chanRunner) NeverReceives(d time.Duration) ChanRunner {
+	r.steps = append(r.steps, func() baseCheck {
+		var received []interface{}
+		if v, ok := r.receive(d); ok {
+			received = append(received, v)
+		}
+		closed := r.got.closed
+		pass := func(interface{}) bool { return len(received) == 0 }
+		expl := func(label string, got interface{}) string {
+			return fmtexpl.Default(label,
+				fmt.Sprintf("to receive no value for %v", d),
+				explainReceived(received, closed),
+			)
+		}
+		return r.newCheck(received, check.NewValueChecker(pass, expl))
+	})
+	return r
+}
+
+func (r *chanRunner) Run(t *testing.T) {
+	t.Helper()
+	r.setResults()
+	r.run(t)
+}
+
+func (r *chanRunner) DryRun() ChanResulter {
+	r.setResults()
+	results := r.got
+	results.baseResults = r.dryRun()
+	return results
+}
+
+// setResults runs the steps on the channel, then sets the checks
+// on their outcomes and on each received value.
+func (r *chanRunner) setResults() {
+	r.got = chanResults{}
+	r.checks = nil
+	for _, step := range r.steps {
+		r.addCheck(step())
+	}
+	for _, c := range r.eachCheckers {
+		r.addCheck(r.eachCheck(c))
+	}
+}
+
+// eachCheck returns a check of the first received value failing c,
+// or a passing check if there is none.
+func (r *chanRunner) eachCheck(c check.ValueChecker) baseCheck {
+	pos := -1
+	for i, v := range r.got.received {
+		if !c.Pass(v) {
+			pos = i
+			break
+		}
+	}
+	return baseCheck{
+		get: func() gottype {
+			if pos == -1 {
+				return nil
+			}
+			return r.got.received[pos]
+		},
+		getLabel: func() string { return fmt.Sprintf("channel received[%d]", pos) },
+		checker: check.NewValueChecker(
+			func(interface{}) bool { return pos == -1 },
+			c.Explain,
+		),
+	}
+}
+
+func (r *chanRunner) newCheck(received []interface{}, c check.ValueChecker) baseCheck {
+	return baseCheck{
+		label:   "channel",
+		get:     func() gottype { return received },
+		checker: c,
+	}
+}
+
+// receiveN receives up to n values within the given duration.
+// It stops early if the channel is closed.
+func (r *chanRunner) receiveN(n int, within time.Duration) []interface{} {
+	received := []interface{}{}
+	deadline := time.Now().Add(within)
+	for len(received) < n {
+		v, ok := r.receive(time.Until(deadline))
+		if !ok {
+			break
+		}
+		received = append(received, v)
+	}
+	return received
+}
+
+// receive receives a value from the channel and records it.
+// It returns false if the channel is closed or if no value was received
+// before the timeout.
+func (r *chanRunner) receive(timeout time.Duration) (interface{}, bool) {
+	if r.got.closed || timeout <= 0 {
+		return nil, false
+	}
+	timer := time.NewTimer(timeout)
+	defer timer.Stop()
+	chosen, v, ok := reflect.Select([]reflect.SelectCase{
+		{Dir: reflect.SelectRecv, Chan: r.ch},
+		{Dir: reflect.SelectRecv, Chan: reflect.ValueOf(timer.C)},
+	})
+	switch {
+	case chosen == 1:
+		return nil, false
+	case !ok:
+		r.got.closed = true
+		return nil, false
+	}
+	r.got.received = append(r.got.received, v.Interface())
+	return v.Interface(), true
+}
+
+// explainReceived returns a description of the values received
+// during a step, and of the channel state at the end of it.
+func explainReceived(received []interface{}, closed bool) string {
+	return fmt.Sprintf(
+		"received %d values %v%s",
+		len(received), received, cond.String(" then closed", "", closed),
+	)
+}
+
+func (r *chanRunner) setChan(ch interface{}) error {
+	chv := reflect.ValueOf(ch)
+	if chv.Kind() != reflect.Chan || chv.Type().ChanDir()&reflect.RecvDir == 0 || chv.IsNil() {
+		return fmt.Errorf("%w: got %T", errChanRunnerChan, ch)
+	}
+	r.ch = chv
+	return nil
+}
+
+func newChanRunner(ch interface{}) ChanRunner {
+	r := &chanRunner{}
+	cond.PanicOnErr(r.setChan(ch))
+	return r
+}
+
+type chanResults struct {
+	baseResults
+	received []interface{}
+	closed   bool
+}
+
+var _ ChanResulter = (*chanResults)(nil)
+
+func (res chanResults) Received() []interface{} {
+	return res.received
+}
+
+func (res chanResults) Closed() bool {
+	return res.closed
+}
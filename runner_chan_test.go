@@ -0,0 +1,121 @@
+package testx_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/drykit-go/testx"
+	"github.com/drykit-go/testx/check"
+	"github.com/drykit-go/testx/checkconv"
+	"github.com/drykit-go/testx/internal/testutil"
+)
+
+// produce sends values on the returned channel after delay,
+// then closes it if closing is true.
+func produce(delay time.Duration, closing bool, values ...int) <-chan int {
+	ch := make(chan int)
+	go func() {
+		time.Sleep(delay)
+		for _, v := range values {
+			ch <- v
+		}
+		if closing {
+			close(ch)
+		}
+	}()
+	return ch
+}
+
+func TestChanRunner(t *testing.T) {
+	t.Run("should pass", func(t *testing.T) {
+		res := testx.Chan(produce(10*time.Millisecond, true, 1, 2, 3, 4)).
+			NeverReceives(time.Millisecond).
+			ReceivesInOrder(1, 2).
+			Receives(1, time.Second).
+			EachReceived(checkconv.FromInt(check.Int.InRange(1, 4))).
+			Closes(time.Second).
+			DryRun()
+
+		exp := baseResults{
+			passed:  true,
+			failed:  false,
+			nPassed: 5,
+			nFailed: 0,
+			nChecks: 5,
+			checks: []testx.CheckResult{
+				{Passed: true, Reason: ""},
+				{Passed: true, Reason: ""},
+				{Passed: true, Reason: ""},
+				{Passed: true, Reason: ""},
+				{Passed: true, Reason: ""},
+			},
+		}
+
+		assertEqualBaseResults(t, res, exp)
+		if got := res.Received(); len(got) != 4 || !res.Closed() {
+			t.Errorf("exp 4 values received then closed, got %v, closed: %v", got, res.Closed())
+		}
+	})
+
+	t.Run("should fail", func(t *testing.T) {
+		res := testx.Chan(produce(0, true, 1, 2, -3)).
+			ReceivesInOrder(2, 1).
+			NeverReceives(time.Second).
+			Receives(2, time.Second).
+			Closes(time.Second).
+			EachReceived(checkconv.FromInt(check.Int.GT(0))).
+			DryRun()
+
+		exp := baseResults{
+			passed:  false,
+			failed:  true,
+			nPassed: 1,
+			nFailed: 4,
+			nChecks: 5,
+			checks: []testx.CheckResult{
+				{Passed: false, Reason: "channel:\nexp to receive [2 1] in order\ngot received 2 values [1 2]"},
+				{Passed: false, Reason: "channel:\nexp to receive no value for 1s\ngot received 1 values [-3]"},
+				{Passed: false, Reason: "channel:\nexp to receive 2 values within 1s\ngot received 0 values [] then closed"},
+				{Passed: true, Reason: ""},
+				{Passed: false, Reason: "channel received[2]:\nexp > 0\ngot -3"},
+			},
+		}
+
+		assertEqualBaseResults(t, res, exp)
+	})
+
+	t.Run("not closed", func(t *testing.T) {
+		res := testx.Chan(produce(0, false, 1)).Closes(10 * time.Millisecond).DryRun()
+		expReason := "channel:\nexp to be closed within 10ms\ngot not closed, received 1 values [1]"
+		if res.Passed() || res.Checks()[0].Reason != expReason {
+			t.Errorf("bad results\nexp %q\ngot %v", expReason, res.Checks())
+		}
+	})
+
+	t.Run("repeated runs", func(t *testing.T) {
+		ch := make(chan int, 2)
+		ch <- 1
+		runner := testx.Chan(ch).ReceivesInOrder().Receives(1, 10*time.Millisecond)
+
+		res := runner.DryRun()
+		if !res.Passed() || len(res.Received()) != 1 {
+			t.Errorf("exp 1 value received, got %v, %v", res.Received(), res.Checks())
+		}
+
+		ch <- 2
+		res = runner.DryRun()
+		if got := res.Received(); !res.Passed() || len(got) != 1 || got[0] != 2 {
+			t.Errorf("exp only the new value received, got %v, %v", got, res.Checks())
+		}
+	})
+
+	t.Run("bad inputs", func(t *testing.T) {
+		var nilChan chan int
+		for _, ch := range []interface{}{nil, 42, nilChan, make(chan<- int)} {
+			func() {
+				defer testutil.AssertPanic(t)
+				testx.Chan(ch)
+			}()
+		}
+	})
+}
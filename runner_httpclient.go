@@ -0,0 +1,244 @@
+package testx
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"net/http"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/drykit-go/testx/check"
+	"github.com/drykit-go/testx/checkconv"
+	"github.com/drykit-go/testx/internal/fmtexpl"
+	"github.com/drykit-go/testx/internal/ioutil"
+)
+
+// StubResponse is a canned response written by the stub server
+// of HTTPClient.
+type StubResponse struct {
+	// Status is the response status code. Default is 200.
+	Status int
+	// Header is added to the response header.
+	Header http.Header
+	// Body is the response body.
+	Body []byte
+	// Delay is the duration to wait before writing the response,
+	// for instance to test a client timeout.
+	Delay time.Duration
+}
+
+var _ HTTPClientRunner = (*httpClientRunner)(nil)
+
+type httpClientRunner struct {
+	baseRunner
+
+	fn  func(c *http.Client, baseURL string) error
+	cfg serverConfig
+
+	responses   []StubResponse
+	reqChecks   []baseCheck
+	errCheckers []check.ValueChecker
+
+	mu  sync.Mutex
+	got httpClientResults
+}
+
+func (r *httpClientRunner) Respond(responses ...StubResponse) HTTPClientRunner {
+	r.responses = append(r.responses, responses...)
+	return r
+}
+
+func (r *httpClientRunner) NRequests(checkers ...check.IntChecker) HTTPClientRunner {
+	for _, c := range checkers {
+		r.reqChecks = append(r.reqChecks, baseCheck{
+			label:   "number of requests",
+			get:     func() gottype { return len(r.got.requests) },
+			checker: checkconv.FromInt(c),
+		})
+	}
+	return r
+}
+
+func (r *httpClientRunner) Request(i int, checkers ...check.HTTPRequestChecker) HTTPClientRunner {
+	for _, c := range checkers {
+		r.reqChecks = append(r.reqChecks, baseCheck{
+			label: fmt.Sprintf("request[%d]", i),
+			get: func() gottype {
+				if i < 0 || i >= len(r.got.requests) {
+					return nil
+				}
+				return r.got.requests[i]
+			},
+			checker: r.requestChecker(c),
+		})
+	}
+	return r
+}
+
+func (r *httpClientRunner) EachRequest(checkers ...check.HTTPRequestChecker) HTTPClientRunner {
+	for _, c := range checkers {
+		r.reqChecks = append(r.reqChecks, r.eachCheck(c))
+	}
+	return r
+}
+
+func (r *httpClientRunner) Err(checkers ...check.ValueChecker) HTTPClientRunner {
+	r.errCheckers = append(r.errCheckers, checkers...)
+	return r
+}
+
+func (r *httpClientRunner) Run(t *testing.T) {
+	t.Helper()
+	r.setResults()
+	r.run(t)
+}
+
+func (r *httpClientRunner) DryRun() HTTPClientResulter {
+	r.setResults()
+	results := r.got
+	results.baseResults = r.dryRun()
+	return results
+}
+
+// setResults calls the tested func with the client and URL
+// of a stub server capturing its requests, then sets the checks.
+func (r *httpClientRunner) setResults() {
+	r.got = httpClientResults{}
+
+	srv := r.cfg.start(http.HandlerFunc(r.serveStub))
+	client := srv.Client()
+	r.got.err = r.fn(client, srv.URL)
+	client.CloseIdleConnections()
+	srv.Close()
+
+	r.checks = append([]baseCheck{}, r.reqChecks...)
+	r.checks = append(r.checks, r.errChecks()...)
+}
+
+// serveStub captures the incoming request and writes the next
+// scripted response. The last one is repeated for extra requests.
+func (r *httpClientRunner) serveStub(w http.ResponseWriter, rq *http.Request) {
+	captured := rq.Clone(rq.Context())
+	body := ioutil.NopRead(&rq.Body)
+	captured.Body = io.NopCloser(bytes.NewReader(body))
+
+	r.mu.Lock()
+	i := len(r.got.requests)
+	r.got.requests = append(r.got.requests, captured)
+	r.mu.Unlock()
+
+	var resp StubResponse
+	switch n := len(r.responses); {
+	case i < n:
+		resp = r.responses[i]
+	case n > 0:
+		resp = r.responses[n-1]
+	}
+
+	time.Sleep(resp.Delay)
+	for k, values := range resp.Header {
+		for _, v := range values {
+			w.Header().Add(k, v)
+		}
+	}
+	if resp.Status != 0 {
+		w.WriteHeader(resp.Status)
+	}
+	w.Write(resp.Body) //nolint:errcheck // the client may have given up
+}
+
+// errChecks returns the checks on the error returned by the tested func,
+// or a single failing check if it returned an unexpected error.
+func (r *httpClientRunner) errChecks() []baseCheck {
+	getErr := func() gottype { return r.got.err }
+	if len(r.errCheckers) == 0 {
+		if r.got.err == nil {
+			return nil
+		}
+		return []baseCheck{{
+			label:   "http client",
+			get:     getErr,
+			checker: noErrorChecker(),
+		}}
+	}
+	checks := []baseCheck{}
+	for _, c := range r.errCheckers {
+		checks = append(checks, baseCheck{
+			label:   "http client error",
+			get:     getErr,
+			checker: c,
+		})
+	}
+	return checks
+}
+
+// eachCheck returns a check of the first captured request failing c,
+// or a passing check if there is none.
+func (r *httpClientRunner) eachCheck(c check.HTTPRequestChecker) baseCheck {
+	pos := -1
+	return baseCheck{
+		get: func() gottype {
+			pos = -1
+			for i, rq := range r.got.requests {
+				if !c.Pass(rq) {
+					pos = i
+					return rq
+				}
+			}
+			return nil
+		},
+		getLabel: func() string { return fmt.Sprintf("request[%d]", pos) },
+		checker: check.NewValueChecker(
+			func(interface{}) bool { return pos == -1 },
+			c.Explain,
+		),
+	}
+}
+
+// requestChecker wraps c so it fails if the checked request
+// was not sent.
+func (r *httpClientRunner) requestChecker(c check.HTTPRequestChecker) check.ValueChecker {
+	pass := func(got interface{}) bool {
+		rq, ok := got.(*http.Request)
+		return ok && c.Pass(rq)
+	}
+	expl := func(label string, got interface{}) string {
+		if got == nil {
+			return fmtexpl.Default(label,
+				"request to be sent",
+				fmt.Sprintf("%d requests sent", len(r.got.requests)),
+			)
+		}
+		return c.Explain(label, got)
+	}
+	return check.NewValueChecker(pass, expl)
+}
+
+func newHTTPClientRunner(
+	fn func(c *http.Client, baseURL string) error,
+	opts ...ServerOption,
+) HTTPClientRunner {
+	r := &httpClientRunner{fn: fn}
+	for _, opt := range opts {
+		opt(&r.cfg)
+	}
+	return r
+}
+
+type httpClientResults struct {
+	baseResults
+	requests []*http.Request
+	err      error
+}
+
+var _ HTTPClientResulter = (*httpClientResults)(nil)
+
+func (res httpClientResults) Requests() []*http.Request {
+	return res.requests
+}
+
+func (res httpClientResults) Err() error {
+	return res.err
+}
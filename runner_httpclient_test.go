@@ -0,0 +1,140 @@
+package testx_test
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/drykit-go/testx"
+	"github.com/drykit-go/testx/check"
+)
+
+// movieClient is a minimal API client used to test HTTPClientRunner.
+type movieClient struct {
+	c       *http.Client
+	baseURL string
+}
+
+func (mc movieClient) rate(id, stars int) error {
+	body := strings.NewReader(fmt.Sprintf(`{"stars":%d}`, stars))
+	rq, _ := http.NewRequest("POST", fmt.Sprintf("%s/movies/%d/ratings", mc.baseURL, id), body)
+	rq.Header.Set("Content-Type", "application/json")
+	resp, err := mc.c.Do(rq)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusCreated {
+		return fmt.Errorf("unexpected status %d", resp.StatusCode)
+	}
+	return nil
+}
+
+func (mc movieClient) title(id int) (string, error) {
+	resp, err := mc.c.Get(fmt.Sprintf("%s/movies/%d?fields=title", mc.baseURL, id))
+	if err != nil {
+		return "", err
+	}
+	defer resp.Body.Close()
+	var movie struct{ Title string }
+	return movie.Title, json.NewDecoder(resp.Body).Decode(&movie)
+}
+
+func TestHTTPClientRunner(t *testing.T) {
+	t.Run("should pass", func(t *testing.T) {
+		var title string
+		res := testx.HTTPClient(func(c *http.Client, baseURL string) error {
+			mc := movieClient{c: c, baseURL: baseURL}
+			if err := mc.rate(42, 5); err != nil {
+				return err
+			}
+			var err error
+			title, err = mc.title(42)
+			return err
+		}, testx.ServerHTTP2()).
+			Respond(
+				testx.StubResponse{Status: http.StatusCreated},
+				testx.StubResponse{
+					Header: http.Header{"Content-Type": {"application/json"}},
+					Body:   []byte(`{"title":"Inception"}`),
+				},
+			).
+			NRequests(check.Int.Is(2)).
+			Request(0,
+				check.HTTPRequest.Method(check.String.Is("POST")),
+				check.HTTPRequest.Path(check.String.Is("/movies/42/ratings")),
+				check.HTTPRequest.Header(check.HTTPHeader.CheckValue("Content-Type", check.String.Is("application/json"))),
+				check.HTTPRequest.Body(check.Bytes.SameJSON([]byte(`{"stars": 5}`))),
+			).
+			Request(1,
+				check.HTTPRequest.Method(check.String.Is("GET")),
+				check.HTTPRequest.Query("fields", check.String.Is("title")),
+			).
+			EachRequest(check.HTTPRequest.Path(check.String.Contains("/movies/42"))).
+			DryRun()
+
+		if !res.Passed() || res.NChecks() != 8 {
+			t.Errorf("exp 8 passing checks, got %v", res.Checks())
+		}
+		if title != "Inception" || len(res.Requests()) != 2 || res.Err() != nil {
+			t.Fatalf("bad results: title %q, %d requests, err %v", title, len(res.Requests()), res.Err())
+		}
+		if proto := res.Requests()[0].Proto; proto != "HTTP/2.0" {
+			t.Errorf("exp request over HTTP/2.0, got %s", proto)
+		}
+	})
+
+	t.Run("should fail", func(t *testing.T) {
+		res := testx.HTTPClient(func(c *http.Client, baseURL string) error {
+			mc := movieClient{c: c, baseURL: baseURL}
+			mc.rate(42, 5) //nolint:errcheck
+			return mc.rate(-1, 6)
+		}).
+			Respond(testx.StubResponse{Status: http.StatusCreated}, testx.StubResponse{Status: 400}).
+			NRequests(check.Int.Is(1)).
+			Request(2, check.HTTPRequest.Method(check.String.Is("POST"))).
+			Request(1, check.HTTPRequest.Path(check.String.Is("/movies/42/ratings"))).
+			EachRequest(check.HTTPRequest.Body(check.Bytes.Is([]byte(`{"stars":5}`)))).
+			DryRun()
+
+		exp := baseResults{
+			passed:  false,
+			failed:  true,
+			nPassed: 0,
+			nFailed: 5,
+			nChecks: 5,
+			checks: []testx.CheckResult{
+				{Passed: false, Reason: "number of requests:\nexp 1\ngot 2"},
+				{Passed: false, Reason: "request[2]:\nexp request to be sent\ngot 2 requests sent"},
+				{Passed: false, Reason: "request[1]:\nexp path to pass StringChecker\ngot explanation: path:\nexp /movies/42/ratings\ngot /movies/-1/ratings"},
+				{Passed: false, Reason: "request[1]:\nexp body to pass BytesChecker\ngot explanation: bytes:\nexp [123 34 115 116 97 114 115 34 58 53 125]\ngot [123 34 115 116 97 114 115 34 58 54 125]"},
+				{Passed: false, Reason: "http client:\nexp no error\ngot unexpected status 400"},
+			},
+		}
+
+		assertEqualBaseResults(t, res, exp)
+	})
+
+	t.Run("client error", func(t *testing.T) {
+		res := testx.HTTPClient(func(c *http.Client, baseURL string) error {
+			c.Timeout = 20 * time.Millisecond
+			_, err := movieClient{c: c, baseURL: baseURL}.title(1)
+			return err
+		}).
+			Respond(testx.StubResponse{Delay: 100 * time.Millisecond}).
+			Request(0, check.HTTPRequest.Path(check.String.Is("/movies/1"))).
+			Err(check.Value.Custom("timeout error", func(got interface{}) bool {
+				var err interface{ Timeout() bool }
+				return errors.As(got.(error), &err) && err.Timeout()
+			})).
+			DryRun()
+
+		if !res.Passed() || res.NChecks() != 2 {
+			t.Errorf("exp 2 passing checks, got %v", res.Checks())
+		}
+	})
+}
@@ -1,6 +1,8 @@
 package testx
 
 import (
+	"bytes"
+	"io"
 	"net/http"
 	"net/http/httptest"
 	"testing"
@@ -19,31 +21,104 @@ type httpHandlerRunner struct {
 
 	in  httpHandlerRunnerInput
 	got httpHandlerRunnerResults
+	// resultChecks are the checks on the handling results, bound to
+	// the runner by setResults. The check on goroutines leaks,
+	// if enabled, is added after them.
+	resultChecks []handlerCheck
+	leaks        leakDetector
+	// measureAllocs is true if allocations checks were added.
+	measureAllocs bool
+	sampler       sampler
 }
 
+// handlerCheck is a check on the handling results. It is not bound
+// to a runner so the copies made by WithRequest run it on their own
+// results.
+type handlerCheck struct {
+	label   string
+	get     handlerGetFunc
+	checker check.ValueChecker
+}
+
+// handlerGetFunc returns the value to be checked from the handling results.
+type handlerGetFunc func(got *httpHandlerRunnerResults) gottype
+
 func (r *httpHandlerRunner) WithRequest(request *http.Request) HTTPHandlerRunner {
 	return &httpHandlerRunner{
-		baseRunner: r.baseRunner,
-		in:         r.in.withRequest(request),
+		in:           r.in.withRequest(request),
+		resultChecks: append([]handlerCheck(nil), r.resultChecks...),
+		leaks:        leakDetector{enabled: r.leaks.enabled},
+
+		measureAllocs: r.measureAllocs,
+		sampler:       r.sampler,
 	}
 }
 
+func (r *httpHandlerRunner) Allocs(checkers ...check.IntChecker) HTTPHandlerRunner {
+	get := func(got *httpHandlerRunnerResults) gottype { return got.allocs }
+	r.addAllocsChecks("handler allocs", get, checkers)
+	return r
+}
+
+func (r *httpHandlerRunner) AllocBytes(checkers ...check.IntChecker) HTTPHandlerRunner {
+	get := func(got *httpHandlerRunnerResults) gottype { return got.allocBytes }
+	r.addAllocsChecks("handler allocated bytes", get, checkers)
+	return r
+}
+
+func (r *httpHandlerRunner) addAllocsChecks(label string, get handlerGetFunc, checkers []check.IntChecker) {
+	r.measureAllocs = true
+	for _, c := range checkers {
+		r.resultChecks = append(r.resultChecks, handlerCheck{
+			label:   label,
+			get:     get,
+			checker: checkconv.FromInt(c),
+		})
+	}
+}
+
+func (r *httpHandlerRunner) NoGoroutineLeaks() HTTPHandlerRunner {
+	r.leaks.enabled = true
+	return r
+}
+
 func (r *httpHandlerRunner) Duration(checkers ...check.DurationChecker) HTTPHandlerRunner {
 	for _, c := range checkers {
-		r.addCheck(baseCheck{
+		r.resultChecks = append(r.resultChecks, handlerCheck{
 			label:   "handling duration",
-			get:     func() gottype { return r.got.duration },
+			get:     func(got *httpHandlerRunnerResults) gottype { return got.duration },
 			checker: checkconv.FromDuration(c),
 		})
 	}
 	return r
 }
 
+func (r *httpHandlerRunner) DurationStats(checkers ...check.DurationStatsChecker) HTTPHandlerRunner {
+	for _, c := range checkers {
+		r.resultChecks = append(r.resultChecks, handlerCheck{
+			label:   "handling duration samples",
+			get:     func(got *httpHandlerRunnerResults) gottype { return got.durations },
+			checker: checkconv.FromDurationStats(c),
+		})
+	}
+	return r
+}
+
+func (r *httpHandlerRunner) Repeat(n int) HTTPHandlerRunner {
+	r.sampler.setRepeat(n)
+	return r
+}
+
+func (r *httpHandlerRunner) Warmup(k int) HTTPHandlerRunner {
+	r.sampler.setWarmup(k)
+	return r
+}
+
 func (r *httpHandlerRunner) Request(checkers ...check.HTTPRequestChecker) HTTPHandlerRunner {
 	for _, c := range checkers {
-		r.addCheck(baseCheck{
+		r.resultChecks = append(r.resultChecks, handlerCheck{
 			label:   "http request",
-			get:     func() gottype { return r.got.request },
+			get:     func(got *httpHandlerRunnerResults) gottype { return got.request },
 			checker: checkconv.FromHTTPRequest(c),
 		})
 	}
@@ -52,9 +127,9 @@ func (r *httpHandlerRunner) Request(checkers ...check.HTTPRequestChecker) HTTPHa
 
 func (r *httpHandlerRunner) Response(checkers ...check.HTTPResponseChecker) HTTPHandlerRunner {
 	for _, c := range checkers {
-		r.addCheck(baseCheck{
+		r.resultChecks = append(r.resultChecks, handlerCheck{
 			label:   "http response",
-			get:     func() gottype { return r.got.response },
+			get:     func(got *httpHandlerRunnerResults) gottype { return got.response },
 			checker: checkconv.FromHTTPResponse(c),
 		})
 	}
@@ -74,18 +149,67 @@ func (r *httpHandlerRunner) DryRun() HandlerResulter {
 	return results
 }
 
+// setResults calls the handler and sets the checks to be run.
 func (r *httpHandlerRunner) setResults() {
 	rr := httptest.NewRecorder()
 	if r.in.rq == nil {
 		r.in.rq = r.defaultRequest()
 	}
 
+	// read the request body before the handler consumes it
+	// so it can be replayed to measure allocations or durations
+	var body []byte
+	if (r.measureAllocs || r.sampler.replays()) && r.in.rq.Body != nil {
+		body = ioutil.NopRead(&r.in.rq.Body)
+	}
+
 	handler := r.in.mw(r.interceptRequest(r.in.hf))
-	r.got.duration = timeFunc(func() {
-		handler(rr, r.in.rq)
-	})
+	r.leaks.start()
+	r.got.durations = r.sampler.run(
+		func() { handler(rr, r.in.rq) },
+		r.replay(r.in.mw(r.in.hf), body),
+	)
+	r.got.duration = r.got.durations[0]
+	r.leaks.stop()
 	r.got.response = rr.Result() //nolint:bodyclose
 	r.got.response.Header = rr.Header()
+
+	if r.measureAllocs {
+		r.got.allocs, r.got.allocBytes = r.allocsPerRequest(body)
+	}
+
+	r.checks = make([]baseCheck, len(r.resultChecks))
+	for i, c := range r.resultChecks {
+		c := c
+		r.checks[i] = baseCheck{
+			label:   c.label,
+			get:     func() gottype { return c.get(&r.got) },
+			checker: c.checker,
+		}
+	}
+	if r.leaks.enabled {
+		r.checks = append(r.checks, r.leaks.check())
+	}
+}
+
+// allocsPerRequest returns the average number of allocations and of bytes
+// allocated by the handler and middlewares to serve the input request
+// with the given body, excluding the ones of the recorder and the request.
+func (r *httpHandlerRunner) allocsPerRequest(body []byte) (allocs, nbytes int) {
+	noop := func(http.ResponseWriter, *http.Request) {}
+	return allocsPerRun(allocRuns, r.replay(r.in.mw(r.in.hf), body), r.replay(noop, body))
+}
+
+// replay returns a func that serves a copy of the input request
+// with the given body to hf, using a new recorder.
+func (r *httpHandlerRunner) replay(hf http.HandlerFunc, body []byte) func() {
+	return func() {
+		rq := r.in.rq.Clone(r.in.rq.Context())
+		if body != nil {
+			rq.Body = io.NopCloser(bytes.NewReader(body))
+		}
+		hf(httptest.NewRecorder(), rq)
+	}
 }
 
 func (r *httpHandlerRunner) defaultRequest() *http.Request {
@@ -128,6 +252,11 @@ type httpHandlerRunnerResults struct {
 	request  *http.Request
 	response *http.Response
 	duration time.Duration
+	// durations are the samples collected via Repeat.
+	durations []time.Duration
+
+	allocs     int
+	allocBytes int
 }
 
 var _ HandlerResulter = (*httpHandlerRunnerResults)(nil)
@@ -151,3 +280,15 @@ func (res httpHandlerRunnerResults) ResponseBody() []byte {
 func (res httpHandlerRunnerResults) ResponseDuration() time.Duration {
 	return res.duration
 }
+
+func (res httpHandlerRunnerResults) ResponseDurations() []time.Duration {
+	return res.durations
+}
+
+func (res httpHandlerRunnerResults) Allocs() int {
+	return res.allocs
+}
+
+func (res httpHandlerRunnerResults) AllocBytes() int {
+	return res.allocBytes
+}
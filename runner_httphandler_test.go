@@ -3,12 +3,16 @@ package testx_test
 import (
 	"context"
 	"encoding/json"
+	"io"
 	"net/http"
+	"net/http/httptest"
+	"strings"
 	"testing"
 	"time"
 
 	testx "github.com/drykit-go/testx"
 	"github.com/drykit-go/testx/check"
+	"github.com/drykit-go/testx/internal/testutil"
 )
 
 func TestHTTPHandlerRunner(t *testing.T) {
@@ -117,3 +121,167 @@ func toHandlerResults(res testx.HandlerResulter) handlerResults {
 		// duration:    res.ResponseDuration(), // cannot predict exact duration
 	}
 }
+
+func TestHTTPHandlerRunnerReuse(t *testing.T) {
+	hf := func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/ok" {
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}
+	base := testx.HTTPHandlerFunc(hf).
+		Repeat(2).
+		Allocs(check.Int.GTE(0)).
+		Response(check.HTTPResponse.StatusCode(check.Int.Is(200)))
+
+	okRes := base.WithRequest(httptest.NewRequest("GET", "/ok", nil)).DryRun()
+	notFoundRes := base.WithRequest(httptest.NewRequest("GET", "/404", nil)).
+		Response(check.HTTPResponse.StatusCode(check.Int.Is(404))).
+		DryRun()
+	baseRes := base.WithRequest(httptest.NewRequest("GET", "/ok", nil)).DryRun()
+
+	if !okRes.Passed() || okRes.NChecks() != 2 || len(okRes.ResponseDurations()) != 2 {
+		t.Errorf("exp 2 passing checks over 2 samples, got %v", okRes.Checks())
+	}
+	if notFoundRes.NPassed() != 2 || notFoundRes.NFailed() != 1 || notFoundRes.ResponseCode() != 404 {
+		t.Errorf("exp base status check to fail only, got %v", notFoundRes.Checks())
+	}
+	if !baseRes.Passed() || baseRes.NChecks() != 2 {
+		t.Errorf("exp base runner not to be altered by its copies, got %v", baseRes.Checks())
+	}
+}
+
+func TestHTTPHandlerRunnerLeaks(t *testing.T) {
+	block := make(chan struct{})
+	defer close(block)
+
+	handler := func(leak bool) http.HandlerFunc {
+		return func(w http.ResponseWriter, _ *http.Request) {
+			done := make(chan struct{})
+			go func() {
+				if leak {
+					<-block
+				}
+				close(done)
+			}()
+			if !leak {
+				<-done
+			}
+		}
+	}
+
+	res := testx.HTTPHandlerFunc(handler(false)).NoGoroutineLeaks().DryRun()
+	if !res.Passed() || res.NChecks() != 1 {
+		t.Errorf("exp no leaks, got %v", res.Checks())
+	}
+
+	res = testx.HTTPHandlerFunc(handler(true)).NoGoroutineLeaks().DryRun()
+	if res.Passed() || !strings.Contains(res.Checks()[0].Reason, "1 leaked goroutines") {
+		t.Errorf("exp 1 leaked goroutine, got %v", res.Checks())
+	}
+
+	rq := httptest.NewRequest("GET", "/", nil)
+	res = testx.HTTPHandlerFunc(handler(true)).NoGoroutineLeaks().WithRequest(rq).DryRun()
+	if res.Passed() || !strings.Contains(res.Checks()[0].Reason, "1 leaked goroutines") {
+		t.Errorf("exp 1 leaked goroutine with request set last, got %v", res.Checks())
+	}
+}
+
+func TestHTTPHandlerRunnerAllocs(t *testing.T) {
+	var sink []byte
+	handler := func(n int) http.HandlerFunc {
+		return func(w http.ResponseWriter, r *http.Request) {
+			if n > 0 {
+				sink = make([]byte, n)
+			}
+		}
+	}
+	rq, _ := http.NewRequest(http.MethodPost, "/", strings.NewReader("body"))
+
+	testx.HTTPHandlerFunc(handler(0)).WithRequest(rq).
+		Allocs(check.Int.Is(0)).
+		AllocBytes(check.Int.Is(0)).
+		Run(t)
+
+	res := testx.HTTPHandlerFunc(handler(4096)).WithRequest(rq).
+		Allocs(check.Int.Is(1)).
+		AllocBytes(check.Int.InRange(4000, 5000)). // approximate measure
+		DryRun()
+	if !res.Passed() {
+		t.Errorf("exp 1 alloc of ~4096 bytes, got %v", res.Checks())
+	}
+
+	res = testx.HTTPHandlerFunc(handler(4096)).
+		Allocs(check.Int.Is(0)).
+		AllocBytes(check.Int.Is(0)).
+		WithRequest(rq).
+		DryRun()
+	if res.Passed() || res.NFailed() != 2 {
+		t.Errorf("exp 2 failed checks with request set last, got %v", res.Checks())
+	}
+	_ = sink
+}
+
+func TestHTTPHandlerRunnerDurationStats(t *testing.T) {
+	var calls int
+	var bodies []string
+	handler := func(w http.ResponseWriter, r *http.Request) {
+		calls++
+		b, _ := io.ReadAll(r.Body)
+		bodies = append(bodies, string(b))
+		time.Sleep(time.Millisecond)
+	}
+	rq, _ := http.NewRequest(http.MethodPost, "/", strings.NewReader("body"))
+
+	res := testx.HTTPHandlerFunc(handler).WithRequest(rq).
+		Warmup(2).
+		Repeat(10).
+		Duration(check.Duration.Over(time.Millisecond)).
+		DurationStats(
+			check.DurationStats.Median(check.Duration.Over(time.Millisecond)),
+			check.DurationStats.Max(check.Duration.Under(time.Millisecond)), // fail
+		).
+		DryRun()
+
+	if calls != 12 || len(res.ResponseDurations()) != 10 {
+		t.Fatalf("exp 12 calls and 10 samples, got %d calls and %d samples", calls, len(res.ResponseDurations()))
+	}
+	for i, b := range bodies {
+		if b != "body" {
+			t.Errorf("call %d: exp replayed body %q, got %q", i, "body", b)
+		}
+	}
+	if res.ResponseDuration() != res.ResponseDurations()[0] {
+		t.Errorf("exp duration to be the first sample, got %v", res.ResponseDuration())
+	}
+	if res.NPassed() != 2 || res.NFailed() != 1 {
+		t.Fatalf("exp 2 passed and 1 failed checks, got %v", res.Checks())
+	}
+	expPrefix := "handling duration samples:\nexp max to pass DurationChecker\ngot explanation: max:\n"
+	reason := res.Checks()[2].Reason
+	if !strings.HasPrefix(reason, expPrefix) || !strings.Contains(reason, "distribution of 10 samples: ") {
+		t.Errorf("bad reason\nexp prefix %q\ngot %q", expPrefix, reason)
+	}
+
+	t.Run("request set last", func(t *testing.T) {
+		res := testx.HTTPHandlerFunc(handler).
+			Repeat(3).
+			DurationStats(check.DurationStats.Median(check.Duration.Over(time.Millisecond))).
+			WithRequest(rq).
+			DryRun()
+		if !res.Passed() || len(res.ResponseDurations()) != 3 {
+			t.Errorf("exp 3 samples passing checks, got %v samples, %v", res.ResponseDurations(), res.Checks())
+		}
+	})
+
+	t.Run("bad inputs", func(t *testing.T) {
+		for _, setSampling := range []func(testx.HTTPHandlerRunner){
+			func(r testx.HTTPHandlerRunner) { r.Repeat(0) },
+			func(r testx.HTTPHandlerRunner) { r.Warmup(-1) },
+		} {
+			func() {
+				defer testutil.AssertPanic(t)
+				setSampling(testx.HTTPHandlerFunc(handler))
+			}()
+		}
+	})
+}
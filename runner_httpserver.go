@@ -0,0 +1,367 @@
+package testx
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"net/http/httptrace"
+	"testing"
+	"time"
+
+	"github.com/drykit-go/cond"
+
+	"github.com/drykit-go/testx/check"
+	"github.com/drykit-go/testx/checkconv"
+	"github.com/drykit-go/testx/internal/fmtexpl"
+	"github.com/drykit-go/testx/internal/ioutil"
+)
+
+// ServerOption configures the server started by HTTPServer.
+type ServerOption func(cfg *serverConfig)
+
+// ServerTLS starts the server with TLS. The client trusts
+// its certificate.
+func ServerTLS() ServerOption {
+	return func(cfg *serverConfig) { cfg.tls = true }
+}
+
+// ServerHTTP2 starts the server with TLS and HTTP/2 enabled.
+// The client attempts HTTP/2.
+func ServerHTTP2() ServerOption {
+	return func(cfg *serverConfig) { cfg.tls, cfg.http2 = true, true }
+}
+
+// ServerConfig calls configure with the underlying *http.Server before
+// it starts, for instance to set its timeouts.
+func ServerConfig(configure func(s *http.Server)) ServerOption {
+	return func(cfg *serverConfig) { cfg.configure = append(cfg.configure, configure) }
+}
+
+type serverConfig struct {
+	tls, http2 bool
+	configure  []func(s *http.Server)
+}
+
+// start starts and returns an httptest.Server serving h
+// according to the config.
+func (cfg serverConfig) start(h http.Handler) *httptest.Server {
+	srv := httptest.NewUnstartedServer(h)
+	for _, configure := range cfg.configure {
+		configure(srv.Config)
+	}
+	srv.EnableHTTP2 = cfg.http2
+	if cfg.tls {
+		srv.StartTLS()
+	} else {
+		srv.Start()
+	}
+	return srv
+}
+
+var _ HTTPServerRunner = (*httpServerRunner)(nil)
+
+type httpServerRunner struct {
+	baseRunner
+
+	handler http.Handler
+	cfg     serverConfig
+	rq      *http.Request
+
+	// responseChecks are run if the client received a response,
+	// errCheckers on the client error if any were added,
+	// durationChecks in any case.
+	responseChecks []baseCheck
+	errCheckers    []check.ValueChecker
+	durationChecks []baseCheck
+	// keepAlive is true if the request is sent a second time
+	// to check the connection is reused.
+	keepAlive bool
+
+	got httpServerResults
+}
+
+func (r *httpServerRunner) WithRequest(request *http.Request) HTTPServerRunner {
+	r.rq = request
+	return r
+}
+
+func (r *httpServerRunner) Response(checkers ...check.HTTPResponseChecker) HTTPServerRunner {
+	for _, c := range checkers {
+		r.responseChecks = append(r.responseChecks, baseCheck{
+			label:   "http response",
+			get:     func() gottype { return r.got.response },
+			checker: checkconv.FromHTTPResponse(c),
+		})
+	}
+	return r
+}
+
+func (r *httpServerRunner) Duration(checkers ...check.DurationChecker) HTTPServerRunner {
+	for _, c := range checkers {
+		r.durationChecks = append(r.durationChecks, baseCheck{
+			label:   "round trip duration",
+			get:     func() gottype { return r.got.duration },
+			checker: checkconv.FromDuration(c),
+		})
+	}
+	return r
+}
+
+func (r *httpServerRunner) FirstByte(checkers ...check.DurationChecker) HTTPServerRunner {
+	for _, c := range checkers {
+		r.responseChecks = append(r.responseChecks, baseCheck{
+			label:   "first response byte",
+			get:     func() gottype { return r.got.firstByte },
+			checker: checkconv.FromDuration(c),
+		})
+	}
+	return r
+}
+
+func (r *httpServerRunner) Proto(checkers ...check.StringChecker) HTTPServerRunner {
+	for _, c := range checkers {
+		r.responseChecks = append(r.responseChecks, baseCheck{
+			label:   "protocol",
+			get:     func() gottype { return r.got.response.Proto },
+			checker: checkconv.FromString(c),
+		})
+	}
+	return r
+}
+
+func (r *httpServerRunner) TransferEncoding(checkers ...check.ValueChecker) HTTPServerRunner {
+	for _, c := range checkers {
+		r.responseChecks = append(r.responseChecks, baseCheck{
+			label:   "transfer encoding",
+			get:     func() gottype { return r.got.response.TransferEncoding },
+			checker: c,
+		})
+	}
+	return r
+}
+
+func (r *httpServerRunner) KeepAlive() HTTPServerRunner {
+	r.keepAlive = true
+	r.responseChecks = append(r.responseChecks, baseCheck{
+		label:   "connection",
+		get:     func() gottype { return r.got.reused },
+		checker: r.keepAliveChecker(),
+	})
+	return r
+}
+
+func (r *httpServerRunner) Err(checkers ...check.ValueChecker) HTTPServerRunner {
+	r.errCheckers = append(r.errCheckers, checkers...)
+	return r
+}
+
+func (r *httpServerRunner) Run(t *testing.T) {
+	t.Helper()
+	r.setResults()
+	r.run(t)
+}
+
+func (r *httpServerRunner) DryRun() HTTPServerResulter {
+	r.setResults()
+	results := r.got
+	results.baseResults = r.dryRun()
+	return results
+}
+
+// setResults starts the server, sends the request with its client,
+// then sets the checks to be run according to the outcome.
+func (r *httpServerRunner) setResults() {
+	r.got = httpServerResults{}
+	if r.rq == nil {
+		r.rq = r.defaultRequest()
+	}
+	// read the request body so it can be sent again
+	var body []byte
+	if r.rq.Body != nil {
+		body = ioutil.NopRead(&r.rq.Body)
+	}
+
+	srv := r.cfg.start(r.handler)
+	defer srv.Close()
+	client := srv.Client()
+	defer client.CloseIdleConnections()
+
+	r.got.roundTrip = r.roundTrip(client, r.newRequest(srv, body))
+	if r.got.err == nil && r.keepAlive {
+		next := r.roundTrip(client, r.newRequest(srv, body))
+		r.got.reused, r.got.keepAliveErr = next.reused, next.err
+	}
+
+	r.checks = append(r.outcomeChecks(), r.durationChecks...)
+}
+
+// newRequest returns a copy of the input request targeting srv,
+// with the given body.
+func (r *httpServerRunner) newRequest(srv *httptest.Server, body []byte) *http.Request {
+	rq := r.rq.Clone(r.rq.Context())
+	rq.RequestURI = "" // must not be set for client requests
+	rq.Host = ""
+	rq.URL.Scheme = cond.String("https", "http", r.cfg.tls)
+	rq.URL.Host = srv.Listener.Addr().String()
+	if body != nil {
+		rq.Body = io.NopCloser(bytes.NewReader(body))
+	}
+	return rq
+}
+
+// roundTrip sends rq with client and reads the whole response body,
+// so the connection can be reused.
+func (r *httpServerRunner) roundTrip(client *http.Client, rq *http.Request) roundTrip {
+	var rt roundTrip
+	var t0 time.Time
+	trace := &httptrace.ClientTrace{
+		GotConn:              func(info httptrace.GotConnInfo) { rt.reused = info.Reused },
+		GotFirstResponseByte: func() { rt.firstByte = time.Since(t0) },
+	}
+	rq = rq.WithContext(httptrace.WithClientTrace(rq.Context(), trace))
+
+	t0 = time.Now()
+	rt.response, rt.err = client.Do(rq)
+	if rt.err == nil {
+		defer rt.response.Body.Close()
+		rt.body, rt.err = io.ReadAll(rt.response.Body)
+		rt.response.Body = io.NopCloser(bytes.NewReader(rt.body))
+	}
+	rt.duration = time.Since(t0)
+	return rt
+}
+
+// outcomeChecks returns the checks on the response if the client
+// received it and the error checks if any were added, or a single
+// failing check if an unexpected error occurred.
+func (r *httpServerRunner) outcomeChecks() []baseCheck {
+	getErr := func() gottype { return r.got.err }
+	if len(r.errCheckers) == 0 {
+		if r.got.err == nil {
+			return r.responseChecks
+		}
+		return []baseCheck{{
+			label:   "http client",
+			get:     getErr,
+			checker: noErrorChecker(),
+		}}
+	}
+
+	checks := []baseCheck{}
+	if r.got.err == nil {
+		checks = append(checks, r.responseChecks...)
+	}
+	for _, c := range r.errCheckers {
+		checks = append(checks, baseCheck{
+			label:   "http client error",
+			get:     getErr,
+			checker: c,
+		})
+	}
+	return checks
+}
+
+// noErrorChecker returns a checker expecting a nil error.
+func noErrorChecker() check.ValueChecker {
+	pass := func(got interface{}) bool { return got == nil }
+	expl := func(label string, got interface{}) string {
+		return fmtexpl.Default(label, "no error", got)
+	}
+	return check.NewValueChecker(pass, expl)
+}
+
+func (r *httpServerRunner) keepAliveChecker() check.ValueChecker {
+	pass := func(got interface{}) bool { return got.(bool) }
+	expl := func(label string, got interface{}) string {
+		return fmtexpl.Default(label,
+			"to be reused by a second request",
+			cond.String(
+				fmt.Sprintf("second request failed: %v", r.got.keepAliveErr),
+				"new connection",
+				r.got.keepAliveErr != nil,
+			),
+		)
+	}
+	return check.NewValueChecker(pass, expl)
+}
+
+func (r *httpServerRunner) defaultRequest() *http.Request {
+	req, _ := http.NewRequest("GET", "/", nil)
+	return req
+}
+
+func newHTTPServerRunner(h http.Handler, opts ...ServerOption) HTTPServerRunner {
+	r := &httpServerRunner{handler: h}
+	for _, opt := range opts {
+		opt(&r.cfg)
+	}
+	return r
+}
+
+// roundTrip is the outcome of a request sent to the server.
+type roundTrip struct {
+	response  *http.Response
+	body      []byte
+	err       error
+	duration  time.Duration
+	firstByte time.Duration
+	reused    bool
+}
+
+type httpServerResults struct {
+	baseResults
+	roundTrip
+	keepAliveErr error
+}
+
+var _ HTTPServerResulter = (*httpServerResults)(nil)
+
+func (res httpServerResults) ResponseHeader() http.Header {
+	if res.response == nil {
+		return nil
+	}
+	return res.response.Header
+}
+
+func (res httpServerResults) ResponseStatus() string {
+	if res.response == nil {
+		return ""
+	}
+	return res.response.Status
+}
+
+func (res httpServerResults) ResponseCode() int {
+	if res.response == nil {
+		return 0
+	}
+	return res.response.StatusCode
+}
+
+func (res httpServerResults) ResponseBody() []byte {
+	return res.body
+}
+
+func (res httpServerResults) ResponseDuration() time.Duration {
+	return res.duration
+}
+
+func (res httpServerResults) FirstByte() time.Duration {
+	return res.firstByte
+}
+
+func (res httpServerResults) Proto() string {
+	if res.response == nil {
+		return ""
+	}
+	return res.response.Proto
+}
+
+func (res httpServerResults) ConnReused() bool {
+	return res.reused
+}
+
+func (res httpServerResults) Err() error {
+	return res.err
+}
@@ -0,0 +1,161 @@
+package testx_test
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/drykit-go/testx"
+	"github.com/drykit-go/testx/check"
+)
+
+func TestHTTPServerRunner(t *testing.T) {
+	echo := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		fmt.Fprintf(w, "%s %s %s", r.Method, r.URL.RequestURI(), body)
+	})
+
+	t.Run("should pass", func(t *testing.T) {
+		rq, _ := http.NewRequest("POST", "http://example.com/echo?id=42", strings.NewReader("hello"))
+		res := testx.HTTPServer(echo).WithRequest(rq).
+			Response(
+				check.HTTPResponse.StatusCode(check.Int.Is(200)),
+				check.HTTPResponse.Body(check.Bytes.Is([]byte("POST /echo?id=42 hello"))),
+			).
+			Duration(check.Duration.Under(time.Second)).
+			Proto(check.String.Is("HTTP/1.1")).
+			KeepAlive().
+			DryRun()
+
+		exp := baseResults{
+			passed:  true,
+			failed:  false,
+			nPassed: 5,
+			nFailed: 0,
+			nChecks: 5,
+			checks: []testx.CheckResult{
+				{Passed: true, Reason: ""},
+				{Passed: true, Reason: ""},
+				{Passed: true, Reason: ""},
+				{Passed: true, Reason: ""},
+				{Passed: true, Reason: ""},
+			},
+		}
+
+		assertEqualBaseResults(t, res, exp)
+		if body := string(res.ResponseBody()); body != "POST /echo?id=42 hello" || !res.ConnReused() {
+			t.Errorf("bad results: body %q, conn reused: %v", body, res.ConnReused())
+		}
+	})
+
+	t.Run("should fail", func(t *testing.T) {
+		closing := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.Header().Set("Connection", "close")
+			w.WriteHeader(http.StatusTeapot)
+		})
+		res := testx.HTTPServer(closing).
+			Response(check.HTTPResponse.StatusCode(check.Int.Is(200))).
+			Proto(check.String.Is("HTTP/2.0")).
+			KeepAlive().
+			DryRun()
+
+		exp := baseResults{
+			passed:  false,
+			failed:  true,
+			nPassed: 0,
+			nFailed: 3,
+			nChecks: 3,
+			checks: []testx.CheckResult{
+				{Passed: false, Reason: "http response:\nexp status code to pass IntChecker\ngot explanation: status code:\nexp 200\ngot 418"},
+				{Passed: false, Reason: "protocol:\nexp HTTP/2.0\ngot HTTP/1.1"},
+				{Passed: false, Reason: "connection:\nexp to be reused by a second request\ngot new connection"},
+			},
+		}
+
+		assertEqualBaseResults(t, res, exp)
+	})
+
+	t.Run("tls and http2", func(t *testing.T) {
+		secure := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if r.TLS == nil {
+				w.WriteHeader(http.StatusUpgradeRequired)
+			}
+		})
+		testx.HTTPServer(secure, testx.ServerTLS()).
+			Response(check.HTTPResponse.StatusCode(check.Int.Is(200))).
+			Proto(check.String.Is("HTTP/1.1")).
+			Run(t)
+		testx.HTTPServer(secure, testx.ServerHTTP2()).
+			Response(check.HTTPResponse.StatusCode(check.Int.Is(200))).
+			Proto(check.String.Is("HTTP/2.0")).
+			KeepAlive().
+			Run(t)
+	})
+
+	t.Run("flush and chunked encoding", func(t *testing.T) {
+		stream := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.Write([]byte("first")) //nolint:errcheck
+			w.(http.Flusher).Flush()
+			time.Sleep(50 * time.Millisecond)
+			w.Write([]byte("second")) //nolint:errcheck
+		})
+		res := testx.HTTPServer(stream).
+			Response(check.HTTPResponse.Body(check.Bytes.Is([]byte("firstsecond")))).
+			TransferEncoding(check.Value.Is([]string{"chunked"})).
+			Duration(check.Duration.Over(50 * time.Millisecond)).
+			DryRun()
+
+		if !res.Passed() || res.FirstByte() >= res.ResponseDuration() {
+			t.Errorf("exp flushed chunked response, got first byte after %v of %v, %v",
+				res.FirstByte(), res.ResponseDuration(), res.Checks())
+		}
+	})
+
+	t.Run("hijacked connection", func(t *testing.T) {
+		hijack := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			conn, buf, _ := w.(http.Hijacker).Hijack()
+			defer conn.Close()
+			buf.WriteString("HTTP/1.1 202 Accepted\r\nContent-Length: 2\r\n\r\nok") //nolint:errcheck
+			buf.Flush()
+		})
+		testx.HTTPServer(hijack).
+			Response(
+				check.HTTPResponse.StatusCode(check.Int.Is(202)),
+				check.HTTPResponse.Body(check.Bytes.Is([]byte("ok"))),
+			).
+			Run(t)
+	})
+
+	t.Run("server timeout", func(t *testing.T) {
+		slow := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			time.Sleep(50 * time.Millisecond)
+		})
+		withWriteTimeout := testx.ServerConfig(func(s *http.Server) {
+			s.WriteTimeout = 10 * time.Millisecond
+		})
+
+		res := testx.HTTPServer(slow, withWriteTimeout).
+			Response(check.HTTPResponse.StatusCode(check.Int.Is(200))).
+			Err(check.Value.Custom("io.EOF", func(got interface{}) bool {
+				err, _ := got.(error)
+				return errors.Is(err, io.EOF)
+			})).
+			Duration(check.Duration.Under(time.Second)).
+			DryRun()
+		if !res.Passed() || res.NChecks() != 2 || res.ResponseCode() != 0 {
+			t.Errorf("exp passing error check, got %v", res.Checks())
+		}
+
+		res = testx.HTTPServer(slow, withWriteTimeout).
+			Response(check.HTTPResponse.StatusCode(check.Int.Is(200))).
+			DryRun()
+		expPrefix := "http client:\nexp no error\ngot "
+		if res.Passed() || res.NChecks() != 1 || !strings.HasPrefix(res.Checks()[0].Reason, expPrefix) {
+			t.Errorf("exp failed client error check, got %v", res.Checks())
+		}
+	})
+}
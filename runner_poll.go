@@ -0,0 +1,151 @@
+package testx
+
+import (
+	"testing"
+	"time"
+
+	"github.com/drykit-go/testx/check"
+	"github.com/drykit-go/testx/internal/fmtexpl"
+)
+
+const (
+	// defaultPollTimeout is the default duration of the polling window
+	// for Eventually and Consistently.
+	defaultPollTimeout = time.Second
+	// defaultPollInterval is the default duration between two polls
+	// for Eventually and Consistently.
+	defaultPollInterval = 10 * time.Millisecond
+)
+
+var _ PollRunner = (*pollRunner)(nil)
+
+type pollRunner struct {
+	baseRunner
+
+	get      func() interface{}
+	checkers []check.ValueChecker
+	// consistently is true if the checkers must pass for the whole
+	// polling window, false if they must pass once.
+	consistently bool
+	timeout      time.Duration
+	interval     time.Duration
+
+	got pollResults
+}
+
+func (r *pollRunner) Timeout(d time.Duration) PollRunner {
+	r.timeout = d
+	return r
+}
+
+func (r *pollRunner) Interval(d time.Duration) PollRunner {
+	r.interval = d
+	return r
+}
+
+func (r *pollRunner) Run(t *testing.T) {
+	t.Helper()
+	r.poll()
+	r.run(t)
+}
+
+func (r *pollRunner) DryRun() PollResulter {
+	r.poll()
+	results := r.got
+	results.baseResults = r.dryRun()
+	return results
+}
+
+// poll calls r.get every r.interval until all checkers pass
+// (Eventually), one checker fails (Consistently), or r.timeout
+// is exceeded. A last poll happens at the deadline so the whole
+// window is covered. It stores the last polled value.
+func (r *pollRunner) poll() {
+	r.got = pollResults{}
+	t0 := time.Now()
+	deadline := t0.Add(r.timeout)
+	for {
+		r.got.value = r.get()
+		r.got.attempts++
+		r.got.elapsed = time.Since(t0)
+		// Eventually is settled once the checkers pass,
+		// Consistently once they fail
+		if r.passAll(r.got.value) != r.consistently {
+			break
+		}
+		remaining := time.Until(deadline)
+		if remaining <= 0 {
+			break
+		}
+		if remaining < r.interval {
+			time.Sleep(remaining)
+		} else {
+			time.Sleep(r.interval)
+		}
+	}
+	r.setChecks()
+}
+
+func (r *pollRunner) passAll(v interface{}) bool {
+	for _, c := range r.checkers {
+		if !c.Pass(v) {
+			return false
+		}
+	}
+	return true
+}
+
+// setChecks sets a check on the last polled value for each checker.
+func (r *pollRunner) setChecks() {
+	r.checks = nil
+	for _, c := range r.checkers {
+		r.addCheck(baseCheck{
+			get:      func() gottype { return r.got.value },
+			getLabel: r.label,
+			checker:  c,
+		})
+	}
+}
+
+func (r *pollRunner) label() string {
+	label := "eventually value"
+	if r.consistently {
+		label = "consistently value"
+	}
+	return fmtexpl.PollLabel(label, r.got.attempts, r.got.elapsed.Round(time.Millisecond))
+}
+
+func newPollRunner(
+	get func() interface{},
+	checkers []check.ValueChecker,
+	consistently bool,
+) PollRunner {
+	return &pollRunner{
+		get:          get,
+		checkers:     checkers,
+		consistently: consistently,
+		timeout:      defaultPollTimeout,
+		interval:     defaultPollInterval,
+	}
+}
+
+type pollResults struct {
+	baseResults
+	value    interface{}
+	attempts int
+	elapsed  time.Duration
+}
+
+var _ PollResulter = (*pollResults)(nil)
+
+func (res pollResults) Value() interface{} {
+	return res.value
+}
+
+func (res pollResults) Attempts() int {
+	return res.attempts
+}
+
+func (res pollResults) Elapsed() time.Duration {
+	return res.elapsed
+}
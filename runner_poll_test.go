@@ -0,0 +1,92 @@
+package testx_test
+
+import (
+	"regexp"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/drykit-go/testx"
+	"github.com/drykit-go/testx/check"
+	"github.com/drykit-go/testx/checkconv"
+)
+
+// counter returns a func returning the number of times it was called.
+func counter() func() interface{} {
+	var n int32
+	return func() interface{} { return int(atomic.AddInt32(&n, 1)) }
+}
+
+func TestEventually(t *testing.T) {
+	t.Run("should pass", func(t *testing.T) {
+		res := testx.Eventually(counter(),
+			checkconv.FromInt(check.Int.GTE(3)),
+			checkconv.FromInt(check.Int.LTE(3)),
+		).Interval(time.Millisecond).DryRun()
+
+		if !res.Passed() || res.NChecks() != 2 {
+			t.Errorf("bad results: %v", res.Checks())
+		}
+		if res.Attempts() != 3 || res.Value() != 3 {
+			t.Errorf("exp 3 attempts and value 3, got %d and %v", res.Attempts(), res.Value())
+		}
+	})
+
+	t.Run("should fail", func(t *testing.T) {
+		res := testx.Eventually(counter(), check.Value.Is(-1)).
+			Timeout(20 * time.Millisecond).
+			Interval(5 * time.Millisecond).
+			DryRun()
+
+		// the last poll happens at the deadline, the upper bound
+		// leaves room for slow runs
+		if res.Passed() || res.Attempts() < 2 || res.Elapsed() < 20*time.Millisecond || res.Elapsed() > time.Second {
+			t.Errorf("bad results: %d attempts in %v", res.Attempts(), res.Elapsed())
+		}
+		rgx := regexp.MustCompile(`^eventually value \(attempt (\d+) after .+\):\nexp -1\ngot (\d+)$`)
+		m := rgx.FindStringSubmatch(res.Checks()[0].Reason)
+		if m == nil || m[1] != m[2] {
+			t.Errorf("bad reason: %q", res.Checks()[0].Reason)
+		}
+	})
+}
+
+func TestConsistently(t *testing.T) {
+	t.Run("should pass", func(t *testing.T) {
+		res := testx.Consistently(counter(), checkconv.FromInt(check.Int.GT(0))).
+			Timeout(20 * time.Millisecond).
+			Interval(5 * time.Millisecond).
+			DryRun()
+
+		if !res.Passed() || res.Attempts() < 2 {
+			t.Errorf("bad results: %v after %d attempts", res.Checks(), res.Attempts())
+		}
+	})
+
+	t.Run("should fail", func(t *testing.T) {
+		res := testx.Consistently(counter(), checkconv.FromInt(check.Int.LT(3))).
+			Interval(time.Millisecond).
+			DryRun()
+
+		if res.Passed() || res.Attempts() != 3 || res.Value() != 3 {
+			t.Errorf("bad results: %v after %d attempts", res.Checks(), res.Attempts())
+		}
+		rgx := regexp.MustCompile(`^consistently value \(attempt 3 after .+\):\nexp < 3\ngot 3$`)
+		if reason := res.Checks()[0].Reason; !rgx.MatchString(reason) {
+			t.Errorf("bad reason: %q", reason)
+		}
+	})
+
+	t.Run("last interval", func(t *testing.T) {
+		t0 := time.Now()
+		early := func() interface{} { return time.Since(t0) < 25*time.Millisecond }
+		res := testx.Consistently(early, check.Value.Is(true)).
+			Timeout(30 * time.Millisecond).
+			Interval(10 * time.Millisecond).
+			DryRun()
+
+		if res.Passed() || res.Elapsed() < 25*time.Millisecond {
+			t.Errorf("exp a poll at the deadline to fail, got %d attempts in %v", res.Attempts(), res.Elapsed())
+		}
+	})
+}
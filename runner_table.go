@@ -1,14 +1,21 @@
 package testx
 
 import (
+	"errors"
 	"fmt"
 	"log"
+	"reflect"
+	"runtime/debug"
+	"sort"
+	"strconv"
 	"strings"
 	"testing"
+	"time"
 
 	"github.com/drykit-go/cond"
 
 	"github.com/drykit-go/testx/check"
+	"github.com/drykit-go/testx/internal/combin"
 	"github.com/drykit-go/testx/internal/fmtexpl"
 	"github.com/drykit-go/testx/internal/reflectutil"
 )
@@ -16,7 +23,12 @@ import (
 var _ TableRunner = (*tableRunner)(nil)
 
 // Case represents a Table test case. It must be provided values for
-// Case.In, and Case.Exp or Case.Not or Case.Pass at least.
+// Case.In or Case.Args, and Case.Exp or Case.Not or Case.Pass at least.
+//
+// Case.ErrIs, Case.ErrAs and Case.ExpErr require the tested func to have
+// a last return value of type error. If none of them is set and the case
+// does not check that value already (via Case.ExpAll, Case.Outs
+// or TableConfig.OutPos), the case expects a nil error.
 type Case struct {
 	// Lab is the label of the current case to be printed if the current
 	// case fails.
@@ -25,6 +37,38 @@ type Case struct {
 	// In is the input value injected in the tested func.
 	In interface{}
 
+	// Args is the complete list of arguments the tested func is called with.
+	// If set, it takes precedence over Case.In, and TableConfig.InPos
+	// and TableConfig.FixedArgs are ignored for the current case.
+	// Each arg must be assignable to the corresponding parameter type
+	// of the tested func, or the runner panics before any call is made.
+	//
+	// 	testx.Table(clamp).Cases([]testx.Case{
+	// 		{Args: testx.Args{5, 0, 10}, Exp: 5},  // clamp(5, 0, 10)
+	// 		{Args: testx.Args{5, 6, 10}, Exp: 6},  // clamp(5, 6, 10)
+	// 		{Args: testx.Args{50, 0, 10}, Exp: 10}, // clamp(50, 0, 10)
+	// 	})
+	//
+	// If the tested func is variadic, any number of trailing args can be
+	// provided for its variadic parameter. They can also be provided
+	// as a single slice of the variadic type:
+	//
+	// 	testx.Table(strings.Join).Cases([]testx.Case{
+	// 		{Args: testx.Args{",", "a", "b"}, Exp: "a,b"},
+	// 		{Args: testx.Args{",", []string{"a", "b"}}, Exp: "a,b"},
+	// 	})
+	Args Args
+
+	// Receiver is the receiver the tested method is called with
+	// for the current case. It overrides the default receiver provided
+	// to Method, and is only valid for a TableRunner created via Method.
+	//
+	// 	testx.Method((*Cache)(nil), "Get").Cases([]testx.Case{
+	// 		{Receiver: NewCache(), In: "k", Exp: nil},
+	// 		{Receiver: NewCache("k", 1), In: "k", Exp: 1},
+	// 	})
+	Receiver interface{}
+
 	// Exp is the value expected to be returned when calling the tested func.
 	// If Case.Exp == nil (zero value), no check is added. This is a necessary
 	// behavior if one wants to use Case.Pass or Case.Not but not Case.Exp.
@@ -44,6 +88,101 @@ type Case struct {
 	// Pass is a slice of check.ValueChecker that the return value of the
 	// tested func is expected to pass.
 	Pass []check.ValueChecker
+
+	// ExpAll is the list of all values expected to be returned when calling
+	// the tested func, in order. Contrary to Case.Exp, it is not restricted
+	// to TableConfig.OutPos, and a nil value expects a nil return value.
+	// If set, its length must equal the number of values returned
+	// by the tested func.
+	//
+	// 	testx.Table(parse).Cases([]testx.Case{
+	// 		{In: "42", ExpAll: testx.Args{numToken, 2, nil}},
+	// 	})
+	ExpAll Args
+
+	// Outs maps return value positions of the tested func, starting at 0,
+	// to the checkers they are expected to pass. It allows to perform checks
+	// on several return values in the same case.
+	//
+	// 	testx.Table(parse).Cases([]testx.Case{
+	// 		{In: "", Outs: map[int][]check.ValueChecker{
+	// 			1: {check.Value.Is(0)},
+	// 			2: {check.Value.NotZero()},
+	// 		}},
+	// 	})
+	Outs map[int][]check.ValueChecker
+
+	// ErrIs is an error expected to be matched by the trailing error
+	// returned by the tested func, using errors.Is.
+	ErrIs error
+
+	// ErrAs is a pointer to a type expected to be matched by the trailing
+	// error returned by the tested func, using errors.As.
+	// It must be a non-nil pointer to a type implementing error
+	// or to an interface type. It is never written to.
+	//
+	// 	testx.Table(open).Cases([]testx.Case{
+	// 		{In: "missing.txt", ErrAs: new(*fs.PathError)},
+	// 	})
+	ErrAs interface{}
+
+	// ExpErr is a string expected to be contained in the message
+	// of the trailing error returned by the tested func.
+	ExpErr string
+
+	// Panics is set to expect the tested func to panic. If so, checks
+	// on the return values are not performed.
+	// Regardless of its value, a panic in the tested func is always
+	// recovered, and reported as a failed check if unexpected.
+	Panics bool
+
+	// PanicPass is a slice of check.ValueChecker that the value recovered
+	// from the panic of the tested func is expected to pass.
+	// It implies Case.Panics.
+	//
+	// 	testx.Table(mustParse).Cases([]testx.Case{
+	// 		{In: "42", Exp: 42},
+	// 		{In: "", Panics: true},
+	// 		{In: "abc", PanicPass: []check.ValueChecker{
+	// 			check.Value.Is("invalid syntax"),
+	// 		}},
+	// 	})
+	PanicPass []check.ValueChecker
+
+	// Setup is called before the tested func for the current case,
+	// after TableConfig.BeforeEach. If it returns a non-nil cleanup func,
+	// the latter is called after the tested func returns or panics,
+	// before TableConfig.AfterEach.
+	// A panic occurring in Setup, cleanup or the hooks of TableConfig
+	// fails the case, whatever its expectations.
+	//
+	// 	testx.Table(countFiles).Cases([]testx.Case{
+	// 		{In: dir, Exp: 1, Setup: func() func() {
+	// 			f, _ := os.Create(filepath.Join(dir, "tmp"))
+	// 			f.Close()
+	// 			return func() { os.Remove(f.Name()) }
+	// 		}},
+	// 	})
+	Setup func() (cleanup func())
+
+	// Timeout is the maximum duration of the call to the tested func
+	// for the current case. It overrides TableConfig.Timeout if set.
+	Timeout time.Duration
+
+	// Expect returns checkers that the tested func output is expected
+	// to pass, built from the arguments of the current case (excluding
+	// the receiver of a tested method). It overrides TableConfig.Expect.
+	Expect func(in ...interface{}) []check.ValueChecker
+
+	// Skip is the reason for skipping the current case. If non-empty,
+	// the tested func is not called and the case subtest is skipped
+	// via t.Skip(Skip).
+	Skip string
+
+	// Only focuses the run on the current case: if set on any case,
+	// all the cases not setting it are skipped. A focused run always
+	// fails, so that a forgotten Only cannot silently pass.
+	Only bool
 }
 
 // TableConfig is configuration object for TableRunner.
@@ -82,24 +221,75 @@ type TableConfig struct {
 	// 		InPos: 1
 	// 		FixedArgs: []interface{0: "myArg0", 2: "myArg2"} // len(FixedArgs) == 3
 	// 	})
+	//
+	// If the tested func is variadic, its variadic parameter counts as one
+	// parameter: the corresponding arg is either a single value or a slice
+	// of the variadic type.
 	FixedArgs Args
+
+	// BeforeEach is called before each case with the case index and value,
+	// before Case.Setup.
+	BeforeEach func(index int, c Case)
+
+	// AfterEach is called after each case with the case index and value,
+	// after the cleanup func returned by Case.Setup. It is called even if
+	// the tested func panics, but before the checks are performed.
+	AfterEach func(index int, c Case)
+
+	// Timeout is the maximum duration of the call to the tested func
+	// for each case, including Case.Setup and the hooks. If exceeded,
+	// the case fails and the runner continues with the remaining cases,
+	// leaving the pending call running in its own goroutine.
+	// It can be overridden per case by Case.Timeout.
+	// A zero value means no timeout.
+	Timeout time.Duration
+
+	// Expect returns checkers that the tested func output is expected
+	// to pass for each case, built from the case arguments (excluding
+	// the receiver of a tested method). It allows invariant-style tables
+	// where the cases only provide inputs. It can be overridden per case
+	// by Case.Expect.
+	//
+	// 	testx.Table(abs).Config(testx.TableConfig{
+	// 		Expect: func(in ...interface{}) []check.ValueChecker {
+	// 			x := in[0].(int)
+	// 			return []check.ValueChecker{check.Value.Is(cond.Int(x, -x, x >= 0))}
+	// 		},
+	// 	}).Cases([]testx.Case{{In: 0}, {In: 42}, {In: -42}})
+	Expect func(in ...interface{}) []check.ValueChecker
+
+	// BenchVerify is set for TableRunner.Bench to run the checks of each
+	// case once before timing it, even though the benchmark func is called
+	// again for each value of b.N. A case that fails its checks is not
+	// benchmarked.
+	BenchVerify bool
 }
 
 // Args is an alias to []interface{}.
 type Args []interface{}
 
+// replaceAt returns a copy of args with the value at pos replaced by arg.
+// The receiver is left untouched, so it can be safely shared by several
+// cases.
 func (args Args) replaceAt(pos int, arg interface{}) Args {
 	if pos >= len(args) {
 		log.Panic("Args.replaceAt(i, v): i is out of range")
 	}
-	args[pos] = arg
-	return args
+	replaced := make(Args, len(args))
+	copy(replaced, args)
+	replaced[pos] = arg
+	return replaced
 }
 
+// String returns the args separated by commas, string values being quoted.
 func (args Args) String() string {
 	var b strings.Builder
 	for i, arg := range args {
-		b.WriteString(fmt.Sprint(arg))
+		if s, ok := arg.(string); ok {
+			b.WriteString(strconv.Quote(s))
+		} else {
+			b.WriteString(fmt.Sprint(arg))
+		}
 		if i != len(args)-1 {
 			b.WriteString(", ")
 		}
@@ -108,80 +298,463 @@ func (args Args) String() string {
 }
 
 type tableRunner struct {
-	baseRunner
-
-	config TableConfig
-	get    func(in interface{}) gottype
+	config   TableConfig
+	cases    []Case
+	parallel bool
+	// jsonCases are the cases added from JSON sources, converted
+	// to the types of the tested func when the cases are made.
+	jsonCases []rawJSONCase
 
 	rfunc *reflectutil.Func
-	args  Args
+
+	// method is the name of the tested method if the runner was created
+	// via Method, in which case the first parameter of rfunc
+	// is the receiver.
+	method string
+	// recv is the default receiver of the tested method.
+	recv interface{}
+
+	// oracle is the reference func the outputs of rfunc are compared to,
+	// if set via Oracle.
+	oracle *reflectutil.Func
 }
 
 func (r *tableRunner) Run(t *testing.T) {
 	t.Helper()
-	cond.PanicOnErr(r.setGetFunc())
-	r.run(t)
+	tcs, err := r.makeCases()
+	cond.PanicOnErr(err)
+	for _, tc := range tcs {
+		tc := tc
+		t.Run(tc.name(), func(t *testing.T) {
+			t.Helper()
+			if tc.skip != "" {
+				t.Skip(tc.skip)
+			}
+			if r.parallel {
+				t.Parallel()
+			}
+			tc.call()
+			tc.run(t)
+		})
+	}
+	if r.focused() {
+		t.Error(r.focusedReason())
+	}
+}
+
+func (r *tableRunner) Bench(b *testing.B) {
+	b.Helper()
+	tcs, err := r.makeCases()
+	cond.PanicOnErr(err)
+	for _, tc := range tcs {
+		tc := tc
+		// b.Run calls the func again each time it raises b.N,
+		// the checks are only run on the first call
+		verified := false
+		b.Run(tc.name(), func(b *testing.B) {
+			b.Helper()
+			switch {
+			case tc.skip != "":
+				b.Skip(tc.skip)
+			case tc.expPanic:
+				b.Skip("skipped: Case.Panics is set")
+			}
+			if r.config.BenchVerify && !verified {
+				verified = true
+				tc.call()
+				tc.run(b)
+				if b.Failed() {
+					return
+				}
+			}
+			tc.bench(b)
+		})
+	}
+	if r.focused() {
+		b.Error(r.focusedReason())
+	}
 }
 
 func (r *tableRunner) DryRun() TableResulter {
-	cond.PanicOnErr(r.setGetFunc())
-	return tableResults{baseResults: r.dryRun()}
+	tcs, err := r.makeCases()
+	cond.PanicOnErr(err)
+	res := tableResults{focused: r.focused()}
+	for _, tc := range tcs {
+		if tc.skip == "" {
+			tc.call()
+		}
+		res.addCase(tc.result())
+	}
+	if res.focused {
+		res.checks = append(res.checks, CheckResult{Reason: r.focusedReason()})
+		res.nFailed++
+	}
+	return res
+}
+
+func (r *tableRunner) Cases(cases []Case) TableRunner {
+	r.cases = append(r.cases, cases...)
+	return r
+}
+
+func (r *tableRunner) Combine(expect func(args Args) Case, values ...Args) TableRunner {
+	return r.combine("Combine", combin.Product, expect, values)
+}
+
+func (r *tableRunner) CombinePairwise(expect func(args Args) Case, values ...Args) TableRunner {
+	return r.combine("CombinePairwise", combin.Pairwise, expect, values)
+}
+
+// combine adds a case for each combination of values generated by gen,
+// values[i] being the candidate values for the ith argument.
+// It panics if any values[i] is empty, as no case would be generated.
+func (r *tableRunner) combine(
+	method string,
+	gen func(sizes []int) [][]int,
+	expect func(args Args) Case,
+	values []Args,
+) TableRunner {
+	sizes := make([]int, len(values))
+	for i, v := range values {
+		if len(v) == 0 {
+			panic(errTableRunnerCombineEmpty(method, r.rfunc.Name, i))
+		}
+		sizes[i] = len(v)
+	}
+	for _, tuple := range gen(sizes) {
+		args := make(Args, len(tuple))
+		for i, vi := range tuple {
+			args[i] = values[i][vi]
+		}
+		var c Case
+		if expect != nil {
+			// pass a copy so expect cannot alter the case args
+			c = expect(append(Args(nil), args...))
+		}
+		c.Args = args
+		r.cases = append(r.cases, c)
+	}
+	return r
+}
+
+func (r *tableRunner) Parallel() TableRunner {
+	r.parallel = true
+	return r
 }
 
-func (r *tableRunner) setGetFunc() error {
+func (r *tableRunner) Oracle(ref interface{}) TableRunner {
+	cond.PanicOnErr(r.setOracle(ref))
+	return r
+}
+
+// makeCases validates the config and returns the runnable test cases
+// built from r.cases, each one with its own copy of the arguments.
+func (r *tableRunner) makeCases() ([]*tableCase, error) {
 	if err := r.validateConfig(); err != nil {
-		return err
+		return nil, err
+	}
+
+	var fixedArgs Args
+	getFixedArgs := func() (Args, error) {
+		if fixedArgs != nil {
+			return fixedArgs, nil
+		}
+		var err error
+		fixedArgs, err = r.makeFixedArgs(r.config)
+		return fixedArgs, err
 	}
 
-	args, err := r.makeFixedArgs(r.rfunc, r.config)
+	cases, err := r.convertJSONCases()
 	if err != nil {
-		return err
+		return nil, err
 	}
 
-	r.get = func(in interface{}) gottype {
-		pin, pout := r.config.InPos, r.config.OutPos
-		r.args = args.replaceAt(pin, in)
-		return r.rfunc.Call(r.args)[pout]
+	focused := r.focused()
+	tcs := make([]*tableCase, len(cases))
+	for i, c := range cases {
+		args, err := r.caseArgs(c, getFixedArgs)
+		if err != nil {
+			return nil, err
+		}
+		recv, err := r.caseReceiver(i, c)
+		if err != nil {
+			return nil, err
+		}
+		if err := r.rfunc.ValidateArgs(r.callArgs(recv, args)); err != nil {
+			return nil, errTableRunnerCaseArgs(r.rfunc.Name, i, err)
+		}
+		if err := r.validateCase(i, c); err != nil {
+			return nil, err
+		}
+		tcs[i] = r.newTableCase(i, c, recv, args)
+		tcs[i].skip = skipReason(c, focused)
 	}
-	return nil
+	return tcs, nil
 }
 
-func (r *tableRunner) Cases(cases []Case) TableRunner {
-	for i, tc := range cases {
-		i, tc := i, tc
+// focused returns true if any case sets Case.Only.
+func (r *tableRunner) focused() bool {
+	for _, c := range r.cases {
+		if c.Only {
+			return true
+		}
+	}
+	return false
+}
+
+// skipReason returns the reason for skipping c, or an empty string
+// if c is to be run. focused reports whether any case sets Case.Only.
+func skipReason(c Case, focused bool) string {
+	switch {
+	case c.Skip != "":
+		return c.Skip
+	case focused && !c.Only:
+		return "skipped: Case.Only is set on another case"
+	}
+	return ""
+}
+
+// focusedReason returns the reason for failing a focused run.
+func (r *tableRunner) focusedReason() string {
+	nOnly := 0
+	for _, c := range r.cases {
+		if c.Only {
+			nOnly++
+		}
+	}
+	return fmt.Sprintf(
+		"Table: Case.Only is set on %d of %d cases, remove it to run all cases",
+		nOnly, len(r.cases),
+	)
+}
 
-		get := func() gottype { return r.get(tc.In) }
+// caseArgs returns the arguments provided by c to call the tested func,
+// excluding the receiver of a tested method.
+func (r *tableRunner) caseArgs(c Case, getFixedArgs func() (Args, error)) (Args, error) {
+	switch {
+	case c.Args != nil:
+		return c.Args, nil
+	case r.nparams() == 0:
+		return Args{}, nil
+	}
+	fixedArgs, err := getFixedArgs()
+	if err != nil {
+		return nil, err
+	}
+	return fixedArgs.replaceAt(r.config.InPos, c.In), nil
+}
 
-		getLabel := func() string {
-			return fmtexpl.TableCaseLabel(r.rfunc.Name, i, tc.Lab, r.args)
+// caseReceiver returns the receiver of the tested method for c:
+// Case.Receiver if set, else the default one.
+func (r *tableRunner) caseReceiver(index int, c Case) (interface{}, error) {
+	if !r.isMethod() {
+		if c.Receiver != nil {
+			return nil, errTableRunnerCaseReceiver(r.rfunc.Name, index)
 		}
+		return nil, nil
+	}
+	if c.Receiver != nil {
+		return c.Receiver, nil
+	}
+	return r.recv, nil
+}
+
+// callArgs returns the complete list of arguments to call rfunc with,
+// that is args prefixed with recv if the tested func is a method.
+func (r *tableRunner) callArgs(recv interface{}, args Args) Args {
+	if !r.isMethod() {
+		return args
+	}
+	return append(Args{recv}, args...)
+}
+
+// labelArgs returns the args to be printed in the case label:
+// the variadic args are expanded and the receiver is excluded.
+func (r *tableRunner) labelArgs(recv interface{}, args Args) Args {
+	expanded := Args(r.rfunc.ExpandArgs(r.callArgs(recv, args)))
+	if r.isMethod() {
+		return expanded[1:]
+	}
+	return expanded
+}
+
+func (r *tableRunner) isMethod() bool {
+	return r.method != ""
+}
+
+// nparams returns the number of parameters of the tested func,
+// excluding the receiver of a tested method.
+func (r *tableRunner) nparams() int {
+	return r.rfunc.Value.Type().NumIn() - cond.Int(1, 0, r.isMethod())
+}
+
+// paramType returns the type of the ith parameter of the tested func,
+// excluding the receiver of a tested method.
+func (r *tableRunner) paramType(i int) reflect.Type {
+	return r.rfunc.Value.Type().In(i + cond.Int(1, 0, r.isMethod()))
+}
+
+func (r *tableRunner) newTableCase(index int, c Case, recv interface{}, args Args) *tableCase {
+	tc := &tableCase{
+		rfunc:         r.rfunc,
+		method:        r.method,
+		index:         index,
+		lab:           c.Lab,
+		recv:          recv,
+		args:          args,
+		callArgs:      r.callArgs(recv, args),
+		labelArgs:     r.labelArgs(recv, args),
+		c:             c,
+		beforeEach:    r.config.BeforeEach,
+		afterEach:     r.config.AfterEach,
+		timeout:       r.config.Timeout,
+		oracle:        r.oracle,
+		expPanic:      c.Panics || len(c.PanicPass) != 0,
+		panicCheckers: c.PanicPass,
+	}
+	if c.Timeout != 0 {
+		tc.timeout = c.Timeout
+	}
 
-		addCaseCheck := func(c check.ValueChecker) {
-			r.addCheck(baseCheck{
-				get:      get,
-				getLabel: getLabel,
-				label:    tc.Lab,
-				checker:  c,
-			})
+	pout := r.config.OutPos
+	get := func() gottype { return tc.outs[pout] }
+
+	addCaseCheck := func(checker check.ValueChecker) {
+		tc.outChecks = append(tc.outChecks, baseCheck{
+			get:      get,
+			getLabel: tc.label,
+			label:    tc.lab,
+			checker:  checker,
+		})
+	}
+
+	// add Case.Exp check
+	if c.Exp != nil {
+		exp := cond.Value(nil, c.Exp, c.Exp == ExpNil)
+		addCaseCheck(check.Value.Is(exp))
+	}
+
+	// add Case.Not checks
+	if len(c.Not) != 0 {
+		addCaseCheck(check.Value.Not(c.Not...))
+	}
+
+	// add Case.Pass checks
+	for _, checker := range c.Pass {
+		tc.outChecks = append(tc.outChecks, baseCheck{
+			get:     get,
+			label:   tc.lab,
+			checker: checker,
+		})
+	}
+
+	// add Case.Expect or TableConfig.Expect checks
+	if expect := r.expectFunc(c); expect != nil {
+		for _, checker := range expect(args...) {
+			addCaseCheck(checker)
 		}
+	}
 
-		// add Case.Exp check
-		if tc.Exp != nil {
-			exp := cond.Value(nil, tc.Exp, tc.Exp == ExpNil)
-			addCaseCheck(check.Value.Is(exp))
+	addOutCheck := func(pos int, checker check.ValueChecker) {
+		tc.outChecks = append(tc.outChecks, baseCheck{
+			get:      func() gottype { return tc.outs[pos] },
+			getLabel: func() string { return tc.outLabel(pos) },
+			label:    tc.lab,
+			checker:  checker,
+		})
+	}
+
+	// add Case.ExpAll checks
+	for pos, exp := range c.ExpAll {
+		exp = cond.Value(nil, exp, exp == ExpNil)
+		addOutCheck(pos, check.Value.Is(exp))
+	}
+
+	// add Case.Outs checks, ordered by position
+	positions := make([]int, 0, len(c.Outs))
+	for pos := range c.Outs {
+		positions = append(positions, pos)
+	}
+	sort.Ints(positions)
+	for _, pos := range positions {
+		for _, checker := range c.Outs[pos] {
+			addOutCheck(pos, checker)
 		}
+	}
 
-		// add Case.Not checks
-		if len(tc.Not) != 0 {
-			addCaseCheck(check.Value.Not(tc.Not...))
+	// add error expectations checks
+	if perr, ok := r.rfunc.ErrOutPos(); ok {
+		for _, checker := range r.errCheckers(c, perr) {
+			addOutCheck(perr, checker)
 		}
+	}
+
+	return tc
+}
+
+// errCheckers returns the checkers for the error expectations of c,
+// the error being returned at position perr. If c has no error
+// expectations and does not check the error value in another way,
+// it returns a checker expecting a nil error.
+func (r *tableRunner) errCheckers(c Case, perr int) []check.ValueChecker {
+	var checkers []check.ValueChecker
+	if c.ErrIs != nil {
+		checkers = append(checkers, errIsChecker(c.ErrIs))
+	}
+	if c.ErrAs != nil {
+		checkers = append(checkers, errAsChecker(c.ErrAs))
+	}
+	if c.ExpErr != "" {
+		checkers = append(checkers, errContainsChecker(c.ExpErr))
+	}
+	if len(checkers) == 0 && !r.checksOut(c, perr) {
+		checkers = append(checkers, check.Value.Is(nil))
+	}
+	return checkers
+}
+
+// checksOut returns true if c has explicit checks on the return value
+// at position pos.
+func (r *tableRunner) checksOut(c Case, pos int) bool {
+	hasOutPosChecks := c.Exp != nil || len(c.Not) != 0 || len(c.Pass) != 0 ||
+		r.expectFunc(c) != nil
+	return r.oracle != nil ||
+		len(c.ExpAll) != 0 ||
+		len(c.Outs[pos]) != 0 ||
+		(pos == r.config.OutPos && hasOutPosChecks)
+}
+
+// expectFunc returns the func building the checkers of c from its args:
+// Case.Expect if set, else TableConfig.Expect.
+func (r *tableRunner) expectFunc(c Case) func(in ...interface{}) []check.ValueChecker {
+	if c.Expect != nil {
+		return c.Expect
+	}
+	return r.config.Expect
+}
 
-		// add Case.Pass checks
-		if len(tc.Pass) != 0 {
-			r.addChecks(tc.Lab, get, tc.Pass)
+// validateCase returns a non-nil error if Case.ExpAll, Case.Outs
+// or the error expectations do not match the return values
+// of the tested func.
+func (r *tableRunner) validateCase(index int, c Case) error {
+	if hasErrExpectations(c) {
+		if _, ok := r.rfunc.ErrOutPos(); !ok {
+			return errTableRunnerCaseNoErrOut(r.rfunc.Name, index)
 		}
 	}
-	return r
+	if c.ErrAs != nil && !isErrorTarget(c.ErrAs) {
+		return errTableRunnerCaseErrAs(index, c.ErrAs)
+	}
+	nout := r.rfunc.Value.Type().NumOut()
+	if n := len(c.ExpAll); n != 0 && n != nout {
+		return errTableRunnerCaseExpAll(r.rfunc.Name, index, n, nout)
+	}
+	for pos := range c.Outs {
+		if pos < 0 || pos >= nout {
+			return errTableRunnerCaseOutPos(r.rfunc.Name, index, pos, nout)
+		}
+	}
+	return nil
 }
 
 func (r *tableRunner) Config(cfg TableConfig) TableRunner {
@@ -208,7 +781,7 @@ func (r *tableRunner) setRfunc(in interface{}) error {
 func (r *tableRunner) validateConfig() error {
 	validPos := func(pos, max int) bool { return pos >= 0 && pos < max }
 	ftyp := r.rfunc.Value.Type()
-	if pin, nin := r.config.InPos, ftyp.NumIn(); !validPos(pin, nin) {
+	if pin, nin := r.config.InPos, r.nparams(); nin != 0 && !validPos(pin, nin) {
 		return errTableRunnerConfigInPos(r.rfunc.Name, pin, nin)
 	}
 	if pout, nout := r.config.OutPos, ftyp.NumOut(); !validPos(pout, nout) {
@@ -217,8 +790,8 @@ func (r *tableRunner) validateConfig() error {
 	return nil
 }
 
-func (r *tableRunner) makeFixedArgs(rfunc *reflectutil.Func, cfg TableConfig) (Args, error) {
-	nparams := rfunc.Value.Type().NumIn()
+func (r *tableRunner) makeFixedArgs(cfg TableConfig) (Args, error) {
+	nparams := r.nparams()
 	nargs := len(cfg.FixedArgs)
 
 	switch d := nparams - nargs; d {
@@ -240,25 +813,542 @@ func (r *tableRunner) makeFixedArgs(rfunc *reflectutil.Func, cfg TableConfig) (A
 	}
 }
 
+// setOracle sets the reference func the outputs of the tested func
+// are compared to. Its type must be the one of the tested func,
+// including the receiver of a tested method.
+func (r *tableRunner) setOracle(ref interface{}) error {
+	oracle, err := reflectutil.NewFunc(ref)
+	if err != nil {
+		return fmt.Errorf("Oracle(func): %w: %v", errTableRunnerOracle, err)
+	}
+	if ftyp, otyp := r.rfunc.Value.Type(), oracle.Value.Type(); !sameSignature(ftyp, otyp) {
+		return errTableRunnerOracleType(oracle.Name, otyp, ftyp)
+	}
+	r.oracle = oracle
+	return nil
+}
+
+// sameSignature returns true if func types a and b have identical
+// parameters and return values.
+func sameSignature(a, b reflect.Type) bool {
+	if a.NumIn() != b.NumIn() || a.NumOut() != b.NumOut() || a.IsVariadic() != b.IsVariadic() {
+		return false
+	}
+	for i := 0; i < a.NumIn(); i++ {
+		if a.In(i) != b.In(i) {
+			return false
+		}
+	}
+	for i := 0; i < a.NumOut(); i++ {
+		if a.Out(i) != b.Out(i) {
+			return false
+		}
+	}
+	return true
+}
+
 func newTableRunner(testedFunc interface{}) TableRunner {
 	r := &tableRunner{}
 	cond.PanicOnErr(r.setRfunc(testedFunc))
 	return r
 }
 
+func (r *tableRunner) setMethod(recv interface{}, name string) error {
+	rtyp := reflect.TypeOf(recv)
+	if rtyp == nil {
+		return fmt.Errorf("Method(nil, %s): %w", name, errTableRunnerMethodRecv)
+	}
+	m, ok := rtyp.MethodByName(name)
+	if !ok {
+		return fmt.Errorf("Method(%s, %s): %w", rtyp, name, errTableRunnerMethodName)
+	}
+	rfunc, err := reflectutil.NewFunc(m.Func.Interface())
+	if err != nil {
+		return fmt.Errorf("Method(%s, %s): %w", rtyp, name, err)
+	}
+	if rfunc.Value.Type().NumOut() == 0 {
+		return fmt.Errorf("Method(%s, %s): %w", rtyp, name, errTableRunnerFuncNumOut)
+	}
+	r.rfunc, r.method, r.recv = rfunc, name, recv
+	return nil
+}
+
+func newMethodTableRunner(recv interface{}, name string) TableRunner {
+	r := &tableRunner{}
+	cond.PanicOnErr(r.setMethod(recv, name))
+	return r
+}
+
+/*
+	Table case
+*/
+
+// tableCase is a single Case ready to be run: it holds the arguments
+// the tested func is called with, and the values it returned
+// or the value recovered from its panic.
+type tableCase struct {
+	baseRunner
+
+	rfunc     *reflectutil.Func
+	method    string
+	index     int
+	lab       string
+	recv      interface{}
+	args      Args
+	callArgs  Args
+	labelArgs Args
+
+	c          Case
+	beforeEach func(int, Case)
+	afterEach  func(int, Case)
+	timeout    time.Duration
+	oracle     *reflectutil.Func
+
+	outChecks     []baseCheck
+	expPanic      bool
+	panicCheckers []check.ValueChecker
+
+	callOutcome
+	elapsed  time.Duration
+	timedOut bool
+	skip     string
+
+	oracleOuts      []interface{}
+	oracleRecovered interface{}
+}
+
+// call calls the tested func with the case args and stores the outputs,
+// or the recovered value if it panicked. It then sets the checks to be run
+// according to the outcome.
+func (tc *tableCase) call() {
+	if tc.timeout == 0 {
+		tc.callOutcome = tc.safeCall()
+	} else {
+		tc.callWithTimeout()
+	}
+	tc.checks = tc.outcomeChecks()
+	if tc.oracle != nil && tc.returned() && tc.hookRecovered == nil && !tc.expPanic {
+		tc.callOracle()
+		tc.checks = append(tc.checks, tc.oracleChecks()...)
+	}
+}
+
+// returned returns true if the tested func returned normally.
+func (tc *tableCase) returned() bool {
+	return !tc.timedOut && !tc.panicked()
+}
+
+// callOracle calls the oracle func with the case args and stores
+// its outputs, or the recovered value if it panicked.
+func (tc *tableCase) callOracle() {
+	defer func() { tc.oracleRecovered = recover() }()
+	tc.oracleOuts = tc.oracle.Call(tc.argsCopy())
+}
+
+// oracleChecks returns the checks comparing each value returned
+// by the tested func with the one returned by the oracle func,
+// or a single failing check if the latter panicked.
+func (tc *tableCase) oracleChecks() []baseCheck {
+	if tc.oracleRecovered != nil {
+		return []baseCheck{{
+			get:      func() gottype { return tc.oracleRecovered },
+			getLabel: tc.label,
+			label:    tc.lab,
+			checker:  tc.oraclePanicChecker(),
+		}}
+	}
+	checks := make([]baseCheck, len(tc.outs))
+	for pos := range tc.outs {
+		pos := pos
+		checks[pos] = baseCheck{
+			get: func() gottype { return tc.outs[pos] },
+			getLabel: func() string {
+				return fmtexpl.TableCaseOracleLabel(tc.outLabel(pos), tc.oracle.Name)
+			},
+			label:   tc.lab,
+			checker: check.Value.Is(tc.oracleOuts[pos]),
+		}
+	}
+	return checks
+}
+
+// callWithTimeout calls the tested func in a new goroutine and waits
+// for it to return until tc.timeout is exceeded.
+func (tc *tableCase) callWithTimeout() {
+	done := make(chan callOutcome, 1)
+	t0 := time.Now()
+	go func() { done <- tc.safeCall() }()
+
+	timer := time.NewTimer(tc.timeout)
+	defer timer.Stop()
+	select {
+	case res := <-done:
+		tc.callOutcome = res
+	case <-timer.C:
+		tc.timedOut = true
+	}
+	tc.elapsed = time.Since(t0)
+}
+
+// bench calls the tested func b.N times with the case args.
+// The hooks and Case.Setup are called around the calls, outside
+// of the timed section. A panic in one of them fails the benchmark.
+func (tc *tableCase) bench(b *testing.B) {
+	b.Helper()
+	b.StopTimer()
+	var res callOutcome
+	tc.withHooks(&res, func() {
+		b.ReportAllocs()
+		b.ResetTimer()
+		b.StartTimer()
+		for i := 0; i < b.N; i++ {
+			tc.rfunc.Call(tc.callArgs)
+		}
+		b.StopTimer()
+	})
+	if res.hookRecovered != nil {
+		tc.callOutcome = res
+		tc.checks = tc.outcomeChecks()
+		tc.run(b)
+	}
+}
+
+// callOutcome is the outcome of a call to the tested func surrounded
+// by the setup and teardown hooks.
+type callOutcome struct {
+	outs      []interface{}
+	recovered interface{}
+	stack     []byte
+
+	// hook is the name of the first hook that panicked,
+	// hookRecovered and hookStack the value recovered and its stack.
+	hook          string
+	hookRecovered interface{}
+	hookStack     []byte
+}
+
+// runHook calls the hook f named name and recovers its panic, if any,
+// recording it if it is the first hook panic. It returns false
+// if f panicked.
+func (o *callOutcome) runHook(name string, f func()) (ok bool) {
+	defer func() {
+		if recovered := recover(); recovered != nil && o.hookRecovered == nil {
+			o.hook, o.hookRecovered, o.hookStack = name, recovered, debug.Stack()
+		}
+	}()
+	f()
+	return true
+}
+
+// safeCall calls the tested func surrounded by the setup and teardown
+// hooks, and recovers any panic occurring in the process.
+func (tc *tableCase) safeCall() (res callOutcome) {
+	tc.withHooks(&res, func() {
+		res.outs, res.recovered, res.stack = tc.recoveredCall()
+	})
+	return res
+}
+
+// withHooks calls f surrounded by the setup and teardown hooks,
+// recording their panics in res. f is not called if BeforeEach
+// or Case.Setup panics.
+func (tc *tableCase) withHooks(res *callOutcome, f func()) {
+	if tc.beforeEach != nil {
+		if !res.runHook("BeforeEach", func() { tc.beforeEach(tc.index, tc.c) }) {
+			return
+		}
+	}
+	if tc.afterEach != nil {
+		defer res.runHook("AfterEach", func() { tc.afterEach(tc.index, tc.c) })
+	}
+	if tc.c.Setup != nil {
+		var cleanup func()
+		if !res.runHook("Setup", func() { cleanup = tc.c.Setup() }) {
+			return
+		}
+		if cleanup != nil {
+			defer res.runHook("cleanup", cleanup)
+		}
+	}
+	f()
+}
+
+// recoveredCall calls the tested func and recovers its panic, if any.
+func (tc *tableCase) recoveredCall() (outs []interface{}, recovered interface{}, stack []byte) {
+	defer func() {
+		if recovered = recover(); recovered != nil {
+			stack = debug.Stack()
+		}
+	}()
+	outs = tc.rfunc.Call(tc.argsCopy())
+	return
+}
+
+// argsCopy returns the args to call the tested func or the oracle with.
+// If an oracle is set, each call gets its own deep copy of the case args
+// so a func mutating its inputs cannot alter the ones of the other.
+func (tc *tableCase) argsCopy() Args {
+	if tc.oracle == nil {
+		return tc.callArgs
+	}
+	args := make(Args, len(tc.callArgs))
+	for i, arg := range tc.callArgs {
+		args[i] = reflectutil.DeepCopy(arg)
+	}
+	return args
+}
+
+func (tc *tableCase) panicked() bool {
+	return tc.recovered != nil
+}
+
+// outcomeChecks returns the checks to be run after the tested func
+// was called: the return values checks if it returned as expected,
+// the panic checks if it panicked as expected, or a single failing
+// check otherwise (unexpected panic or return, timeout).
+func (tc *tableCase) outcomeChecks() []baseCheck {
+	getRecovered := func() gottype { return tc.recovered }
+
+	if tc.timedOut {
+		return []baseCheck{{
+			get:      func() gottype { return tc.elapsed },
+			getLabel: tc.label,
+			label:    tc.lab,
+			checker:  tc.timeoutChecker(),
+		}}
+	}
+
+	if tc.hookRecovered != nil {
+		return []baseCheck{{
+			get:      func() gottype { return tc.hookRecovered },
+			getLabel: func() string { return fmtexpl.TableCaseHookLabel(tc.label(), tc.hook) },
+			label:    tc.lab,
+			checker:  tc.hookPanicChecker(),
+		}}
+	}
+
+	if !tc.expPanic {
+		if !tc.panicked() {
+			return tc.outChecks
+		}
+		return []baseCheck{{
+			get:      getRecovered,
+			getLabel: tc.label,
+			label:    tc.lab,
+			checker:  tc.noPanicChecker(),
+		}}
+	}
+
+	checks := []baseCheck{{
+		get:      func() gottype { return tc.outs },
+		getLabel: tc.label,
+		label:    tc.lab,
+		checker:  tc.panicChecker(),
+	}}
+	if !tc.panicked() {
+		return checks
+	}
+	for _, checker := range tc.panicCheckers {
+		checks = append(checks, baseCheck{
+			get:      getRecovered,
+			getLabel: tc.panicLabel,
+			label:    tc.lab,
+			checker:  checker,
+		})
+	}
+	return checks
+}
+
+func (tc *tableCase) timeoutChecker() check.ValueChecker {
+	pass := func(interface{}) bool { return !tc.timedOut }
+	expl := func(label string, got interface{}) string {
+		return fmtexpl.Default(label,
+			fmt.Sprintf("to return within %v", tc.timeout),
+			fmt.Sprintf("timeout after %v", got),
+		)
+	}
+	return check.NewValueChecker(pass, expl)
+}
+
+func (tc *tableCase) hookPanicChecker() check.ValueChecker {
+	pass := func(interface{}) bool { return false }
+	expl := func(label string, got interface{}) string {
+		return fmtexpl.Default(label,
+			"no panic",
+			fmt.Sprintf("panic: %v\n\n%s", got, tc.hookStack),
+		)
+	}
+	return check.NewValueChecker(pass, expl)
+}
+
+func (tc *tableCase) oraclePanicChecker() check.ValueChecker {
+	pass := func(interface{}) bool { return false }
+	expl := func(label string, got interface{}) string {
+		return fmtexpl.Default(label,
+			fmt.Sprintf("%s to return", tc.oracle.Name),
+			fmt.Sprintf("%s panic: %v", tc.oracle.Name, got),
+		)
+	}
+	return check.NewValueChecker(pass, expl)
+}
+
+func (tc *tableCase) noPanicChecker() check.ValueChecker {
+	pass := func(interface{}) bool { return !tc.panicked() }
+	expl := func(label string, got interface{}) string {
+		return fmtexpl.Default(label,
+			"no panic",
+			fmt.Sprintf("panic: %v\n\n%s", got, tc.stack),
+		)
+	}
+	return check.NewValueChecker(pass, expl)
+}
+
+func (tc *tableCase) panicChecker() check.ValueChecker {
+	pass := func(interface{}) bool { return tc.panicked() }
+	expl := func(label string, got interface{}) string {
+		return fmtexpl.Default(label,
+			"panic",
+			fmt.Sprintf("return values %v", got),
+		)
+	}
+	return check.NewValueChecker(pass, expl)
+}
+
+// label returns the label of the case to be printed on failure.
+func (tc *tableCase) label() string {
+	if tc.method != "" {
+		return fmtexpl.TableCaseMethodLabel(tc.recv, tc.method, tc.index, tc.lab, tc.labelArgs)
+	}
+	return fmtexpl.TableCaseLabel(tc.rfunc.Name, tc.index, tc.lab, tc.labelArgs)
+}
+
+// result runs the case checks without *testing.T and returns
+// the corresponding CaseResult.
+func (tc *tableCase) result() CaseResult {
+	return CaseResult{
+		Index:    tc.index,
+		Label:    tc.lab,
+		Receiver: tc.recv,
+		Args:     tc.args,
+		Outs:     tc.outs,
+		Panic:    tc.recovered,
+		TimedOut: tc.timedOut,
+		Skipped:  tc.skip != "",
+		Skip:     tc.skip,
+		Checks:   tc.dryRun().checks,
+	}
+}
+
+// outLabel returns the label of the case for a check
+// on the return value at position pos.
+func (tc *tableCase) outLabel(pos int) string {
+	return fmtexpl.TableCaseOutLabel(tc.label(), pos)
+}
+
+// panicLabel returns the label of the case for a check
+// on the recovered panic value.
+func (tc *tableCase) panicLabel() string {
+	return fmtexpl.TableCasePanicLabel(tc.label())
+}
+
+// name returns the name of the subtest the case is run in:
+// Case.Lab if set, else the default case label.
+func (tc *tableCase) name() string {
+	if tc.lab != "" {
+		return tc.lab
+	}
+	return tc.label()
+}
+
 /*
 	Results
 */
 
+// CaseResult is the result of a single TableRunner test case
+// after a dry run.
+type CaseResult struct {
+	// Index is the position of the case in the cases provided
+	// to TableRunner, starting at 0.
+	Index int
+	// Label is the label of the case as set in Case.Lab.
+	Label string
+	// Receiver is the receiver the tested method was called with,
+	// or nil if the tested func is not a method.
+	Receiver interface{}
+	// Args are the arguments the tested func was called with,
+	// excluding the receiver of a tested method.
+	Args Args
+	// Outs are the values returned by the tested func.
+	// It is nil if the tested func panicked.
+	Outs []interface{}
+	// Panic is the value recovered from the panic of the tested func,
+	// or nil if it did not panic.
+	Panic interface{}
+	// TimedOut is true if the call to the tested func exceeded
+	// the case timeout.
+	TimedOut bool
+	// Skipped is true if the case was skipped, in which case the tested
+	// func was not called and Checks is empty.
+	Skipped bool
+	// Skip is the reason the case was skipped, if so.
+	Skip string
+	// Checks lists the results of the checks run on the case.
+	Checks []CheckResult
+}
+
+// Passed returns true if all checks of the case passed.
+func (cr CaseResult) Passed() bool {
+	for _, c := range cr.Checks {
+		if !c.Passed {
+			return false
+		}
+	}
+	return true
+}
+
+// Failed returns true if one check or more of the case failed.
+func (cr CaseResult) Failed() bool {
+	return !cr.Passed()
+}
+
 type tableResults struct {
 	baseResults
+	cases    []CaseResult
+	nSkipped int
+	focused  bool
+}
+
+func (res *tableResults) addCase(cr CaseResult) {
+	res.cases = append(res.cases, cr)
+	if cr.Skipped {
+		res.nSkipped++
+		return
+	}
+	for _, c := range cr.Checks {
+		res.checks = append(res.checks, c)
+		if !c.Passed {
+			res.nFailed++
+		}
+	}
+}
+
+func (res tableResults) Cases() []CaseResult {
+	return res.cases
+}
+
+func (res tableResults) NSkipped() int {
+	return res.nSkipped
+}
+
+func (res tableResults) Focused() bool {
+	return res.focused
 }
 
 func (res tableResults) PassedAt(i int) bool {
-	if i >= len(res.checks) {
+	if i < 0 || i >= len(res.cases) {
 		panic(fmt.Sprintf("TableResults: index %d is out of range", i))
 	}
-	return res.checks[i].Passed
+	return res.cases[i].Passed()
 }
 
 func (res tableResults) FailedAt(i int) bool {
@@ -266,18 +1356,80 @@ func (res tableResults) FailedAt(i int) bool {
 }
 
 func (res tableResults) PassedLabel(label string) bool {
-	for _, c := range res.checks {
-		if c.label == label {
-			return c.Passed
+	found := false
+	for _, c := range res.cases {
+		if c.Label != label {
+			continue
 		}
+		if c.Failed() {
+			return false
+		}
+		found = true
+	}
+	if !found {
+		panic(fmt.Sprintf("TableResults: no test case with label %s", label))
 	}
-	panic(fmt.Sprintf("TableResults: no test case with label %s", label))
+	return true
 }
 
 func (res tableResults) FailedLabel(label string) bool {
 	return !res.PassedLabel(label)
 }
 
+/*
+	Error checkers
+*/
+
+func hasErrExpectations(c Case) bool {
+	return c.ErrIs != nil || c.ErrAs != nil || c.ExpErr != ""
+}
+
+// isErrorTarget returns true if target is a valid target for errors.As,
+// i.e. a non-nil pointer to a type implementing error or to an interface.
+func isErrorTarget(target interface{}) bool {
+	v := reflect.ValueOf(target)
+	if v.Kind() != reflect.Ptr || v.IsNil() {
+		return false
+	}
+	elem := v.Type().Elem()
+	return elem.Kind() == reflect.Interface || elem.Implements(reflectutil.ErrorType)
+}
+
+func errIsChecker(target error) check.ValueChecker {
+	pass := func(got interface{}) bool {
+		err, _ := got.(error)
+		return errors.Is(err, target)
+	}
+	expl := func(label string, got interface{}) string {
+		return fmtexpl.Default(label, fmt.Sprintf("error matching %v", target), got)
+	}
+	return check.NewValueChecker(pass, expl)
+}
+
+func errAsChecker(target interface{}) check.ValueChecker {
+	ttyp := reflect.TypeOf(target).Elem()
+	pass := func(got interface{}) bool {
+		err, _ := got.(error)
+		// use a new target for each call so the input one is never written
+		return errors.As(err, reflect.New(ttyp).Interface())
+	}
+	expl := func(label string, got interface{}) string {
+		return fmtexpl.Default(label, fmt.Sprintf("error as %s", ttyp), got)
+	}
+	return check.NewValueChecker(pass, expl)
+}
+
+func errContainsChecker(msg string) check.ValueChecker {
+	pass := func(got interface{}) bool {
+		err, _ := got.(error)
+		return err != nil && strings.Contains(err.Error(), msg)
+	}
+	expl := func(label string, got interface{}) string {
+		return fmtexpl.Default(label, fmt.Sprintf("error containing %q", msg), got)
+	}
+	return check.NewValueChecker(pass, expl)
+}
+
 /*
 	ExpNil
 */
@@ -0,0 +1,129 @@
+package testx
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"reflect"
+
+	"github.com/drykit-go/cond"
+
+	"github.com/drykit-go/testx/internal/reflectutil"
+)
+
+// jsonCase is the JSON representation of a Case.
+type jsonCase struct {
+	Lab string            `json:"lab"`
+	In  json.RawMessage   `json:"in"`
+	Exp json.RawMessage   `json:"exp"`
+	Not []json.RawMessage `json:"not"`
+}
+
+// rawJSONCase is a case decoded from a JSON source whose values
+// are not converted yet. It is stored at r.cases[index].
+type rawJSONCase struct {
+	index  int
+	source string
+	pos    int
+	jc     jsonCase
+}
+
+func (r *tableRunner) CasesFromFile(path string) TableRunner {
+	f, err := os.Open(path) //nolint:gosec // path is set by the test author
+	if err != nil {
+		cond.PanicOnErr(fmt.Errorf("%w: %v", errTableRunnerCasesJSON, err))
+	}
+	defer f.Close()
+	return r.casesFromJSON(path, f)
+}
+
+func (r *tableRunner) CasesFromReader(rd io.Reader) TableRunner {
+	return r.casesFromJSON("CasesFromReader", rd)
+}
+
+// casesFromJSON decodes a JSON array of cases from rd and adds them
+// to r.cases. Their values are converted by convertJSONCases when
+// the cases are made, once the config is final.
+func (r *tableRunner) casesFromJSON(source string, rd io.Reader) TableRunner {
+	var jcases []jsonCase
+	if err := json.NewDecoder(rd).Decode(&jcases); err != nil {
+		cond.PanicOnErr(errTableRunnerJSON(source, err))
+	}
+	for i, jc := range jcases {
+		r.jsonCases = append(r.jsonCases, rawJSONCase{
+			index:  len(r.cases),
+			source: source,
+			pos:    i,
+			jc:     jc,
+		})
+		r.cases = append(r.cases, Case{Lab: jc.Lab})
+	}
+	return r
+}
+
+// convertJSONCases returns a copy of r.cases in which the cases decoded
+// from JSON have their values converted to the types of the tested func
+// parameter at InPos and return value at OutPos. The config must be valid.
+func (r *tableRunner) convertJSONCases() ([]Case, error) {
+	if len(r.jsonCases) == 0 {
+		return r.cases, nil
+	}
+	var intyp reflect.Type
+	if r.nparams() != 0 {
+		intyp = r.paramType(r.config.InPos)
+	}
+	outtyp := r.rfunc.Value.Type().Out(r.config.OutPos)
+
+	cases := append([]Case(nil), r.cases...)
+	for _, raw := range r.jsonCases {
+		c, err := raw.jc.toCase(intyp, outtyp)
+		if err != nil {
+			return nil, errTableRunnerJSONCase(raw.source, raw.pos, err)
+		}
+		cases[raw.index] = c
+	}
+	return cases, nil
+}
+
+// toCase returns a Case from jc, converting its input and output values
+// to intyp and outtyp respectively. An explicit null value for "exp"
+// is interpreted as ExpNil.
+func (jc jsonCase) toCase(intyp, outtyp reflect.Type) (Case, error) {
+	c := Case{Lab: jc.Lab}
+
+	if jc.In != nil {
+		in, err := reflectutil.UnmarshalJSONAs(jc.In, intyp)
+		if err != nil {
+			return c, fmt.Errorf("in: %w", err)
+		}
+		c.In = in
+	}
+
+	if jc.Exp != nil {
+		if isJSONNull(jc.Exp) {
+			c.Exp = ExpNil
+		} else {
+			exp, err := reflectutil.UnmarshalJSONAs(jc.Exp, outtyp)
+			if err != nil {
+				return c, fmt.Errorf("exp: %w", err)
+			}
+			c.Exp = exp
+		}
+	}
+
+	for j, rawNot := range jc.Not {
+		not, err := reflectutil.UnmarshalJSONAs(rawNot, outtyp)
+		if err != nil {
+			return c, fmt.Errorf("not[%d]: %w", j, err)
+		}
+		c.Not = append(c.Not, not)
+	}
+
+	return c, nil
+}
+
+func isJSONNull(raw json.RawMessage) bool {
+	return bytes.Equal(bytes.TrimSpace(raw), []byte("null"))
+}
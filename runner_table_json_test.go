@@ -0,0 +1,80 @@
+package testx_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/drykit-go/testx"
+	"github.com/drykit-go/testx/internal/testutil"
+)
+
+func TestTableRunnerCasesFromFile(t *testing.T) {
+	t.Run("valid file", func(t *testing.T) {
+		res := testx.Table(double).CasesFromFile("testdata/table_cases.json").DryRun()
+
+		assertEqualTableResults(t, res, tableResults{
+			baseResults: baseResults{
+				passed:  false,
+				failed:  true,
+				nPassed: 4,
+				nFailed: 1,
+				nChecks: 5,
+				checks: []testx.CheckResult{
+					{Passed: true},
+					{Passed: true},
+					{Passed: true},
+					{Passed: true},
+					{Passed: false, Reason: "Table.Cases[3] testx_test.double(1):\nexp 3\ngot 2"},
+				},
+			},
+			passedLabel: map[string]bool{"positive number": true, "zero": true},
+		})
+	})
+
+	t.Run("missing file", func(t *testing.T) {
+		defer testutil.AssertPanicMessage(t,
+			"invalid JSON cases: open testdata/missing.json: no such file or directory",
+		)
+		testx.Table(double).CasesFromFile("testdata/missing.json")
+	})
+}
+
+func TestTableRunnerCasesFromReader(t *testing.T) {
+	t.Run("converts values to func types", func(t *testing.T) {
+		testx.Table(parseDigits).Config(testx.TableConfig{OutPos: 2}).
+			CasesFromReader(strings.NewReader(`[
+				{"in": "42", "exp": null},
+				{"in": "4a", "not": [null]}
+			]`)).
+			Run(t)
+
+		// Config called after CasesFromReader
+		testx.Table(strings.Repeat).
+			CasesFromReader(strings.NewReader(`[{"in": 3, "exp": "ababab"}]`)).
+			Config(testx.TableConfig{InPos: 1, FixedArgs: testx.Args{"ab"}}).
+			Run(t)
+
+		f := func(p struct{ X, Y uint8 }) []uint8 { return []uint8{p.X, p.Y} }
+		testx.Table(f).
+			CasesFromReader(strings.NewReader(`[{"in": {"X": 1, "Y": 2}, "exp": [1, 2]}]`)).
+			Run(t)
+	})
+
+	t.Run("invalid json", func(t *testing.T) {
+		defer testutil.AssertPanicMessage(t,
+			"invalid JSON cases: CasesFromReader: unexpected EOF",
+		)
+		testx.Table(double).CasesFromReader(strings.NewReader(`[{"in": 1}`))
+	})
+
+	t.Run("conversion error", func(t *testing.T) {
+		defer testutil.AssertPanicMessage(t,
+			"invalid JSON cases: CasesFromReader: Cases[1]: exp: "+
+				"json: cannot unmarshal string into Go value of type int",
+		)
+		testx.Table(double).CasesFromReader(strings.NewReader(`[
+			{"in": 1, "exp": 2},
+			{"in": 1, "exp": "2"}
+		]`)).DryRun()
+	})
+}
@@ -2,14 +2,19 @@ package testx_test
 
 import (
 	"errors"
+	"fmt"
 	"log"
 	"reflect"
+	"sort"
 	"strings"
+	"sync/atomic"
 	"testing"
+	"time"
 
 	"github.com/drykit-go/testx"
 	"github.com/drykit-go/testx/check"
 	"github.com/drykit-go/testx/checkconv"
+	"github.com/drykit-go/testx/internal/testutil"
 )
 
 // Tests
@@ -137,6 +142,884 @@ func TestTableRunner(t *testing.T) {
 	})
 }
 
+func TestTableRunnerSubtests(t *testing.T) {
+	t.Run("one subtest per case", func(t *testing.T) {
+		testx.Table(double).Cases([]testx.Case{
+			{In: 0, Exp: 0, Lab: "labeled case"},
+			{In: 1, Exp: 2},
+		}).Run(t)
+
+		for _, name := range []string{
+			"labeled case",
+			"Table.Cases[1] testx_test.double(1)",
+		} {
+			assertSubtestRun(t, name)
+		}
+	})
+
+	t.Run("parallel cases", func(t *testing.T) {
+		var ncalls int32
+		f := func(n int) int {
+			atomic.AddInt32(&ncalls, 1)
+			return n
+		}
+
+		t.Run("table", func(t *testing.T) {
+			testx.Table(f).Cases([]testx.Case{
+				{In: 0, Exp: 0},
+				{In: 1, Exp: 1},
+			}).Parallel().Run(t)
+
+			// parallel subtests are paused until their parent returns
+			if n := atomic.LoadInt32(&ncalls); n != 0 {
+				t.Errorf("exp cases to be run in parallel, %d were run sequentially", n)
+			}
+		})
+
+		if n := atomic.LoadInt32(&ncalls); n != 2 {
+			t.Errorf("exp 2 calls, got %d", n)
+		}
+	})
+
+	t.Run("fixed args are not mutated", func(t *testing.T) {
+		a0, a2 := expFixedArgs["a0"], expFixedArgs["a2"]
+		fixedArgs := testx.Args{0: a0, 2: a2}
+		testx.Table(evenMultipleIn).Config(testx.TableConfig{
+			InPos:     1,
+			FixedArgs: fixedArgs,
+		}).Cases([]testx.Case{
+			{In: 42, Exp: true},
+			{In: 99, Exp: false},
+		}).Parallel().Run(t)
+
+		if fixedArgs[1] != nil {
+			t.Errorf("FixedArgs was mutated: %v", fixedArgs)
+		}
+	})
+}
+
+func TestTableRunnerCaseArgs(t *testing.T) {
+	t.Run("full args per case", func(t *testing.T) {
+		res := testx.Table(clamp).Cases([]testx.Case{
+			{Args: testx.Args{5, 0, 10}, Exp: 5},
+			{Args: testx.Args{5, 6, 10}, Exp: 6},
+			{Args: testx.Args{50, 0, 10}, Exp: 10},
+			{Args: testx.Args{50, 0, 10}, Exp: 50}, // fail
+		}).DryRun()
+
+		if n := res.NFailed(); n != 1 {
+			t.Errorf("exp 1 failed check, got %d", n)
+		}
+		exp := "Table.Cases[3] testx_test.clamp(50, 0, 10):\nexp 50\ngot 10"
+		if got := res.Checks()[3].Reason; got != exp {
+			t.Errorf("bad reason\nexp %s\ngot %s", exp, got)
+		}
+	})
+
+	t.Run("mixed with Case.In", func(t *testing.T) {
+		testx.Table(clamp).Config(testx.TableConfig{
+			FixedArgs: testx.Args{0, 10},
+		}).Cases([]testx.Case{
+			{In: 5, Exp: 5},
+			{Args: testx.Args{5, 6, 10}, Exp: 6},
+		}).Run(t)
+	})
+
+	t.Run("bad number of args", func(t *testing.T) {
+		defer testutil.AssertPanicMessage(t,
+			"invalid Case: Cases[1]: cannot call testx_test.clamp: "+
+				"invalid arguments: exp 3 args, got 2",
+		)
+		testx.Table(clamp).Cases([]testx.Case{
+			{Args: testx.Args{5, 0, 10}, Exp: 5},
+			{Args: testx.Args{5, 0}, Exp: 5},
+		}).DryRun()
+	})
+
+	t.Run("bad arg type", func(t *testing.T) {
+		called := false
+		f := func(n int, s string) bool {
+			called = true
+			return true
+		}
+		defer func() {
+			if called {
+				t.Error("exp tested func not to be called")
+			}
+		}()
+		defer testutil.AssertPanic(t)
+		testx.Table(f).Cases([]testx.Case{
+			{Args: testx.Args{1, "ok"}, Exp: true},
+			{Args: testx.Args{"ko", 1}, Exp: true},
+		}).DryRun()
+	})
+}
+
+func TestTableRunnerCaseOuts(t *testing.T) {
+	t.Run("ExpAll", func(t *testing.T) {
+		res := testx.Table(parseDigits).Cases([]testx.Case{
+			{In: "42", ExpAll: testx.Args{42, 2, nil}},
+			{In: "4a", ExpAll: testx.Args{4, 1, errNotDigit}},
+			{In: "4a", ExpAll: testx.Args{4, 1, nil}}, // fail
+		}).DryRun()
+
+		if n := res.NChecks(); n != 9 {
+			t.Errorf("exp 9 checks, got %d", n)
+		}
+		if !res.PassedAt(0) || !res.PassedAt(1) || res.PassedAt(2) {
+			t.Errorf("bad results: %v", res.Checks())
+		}
+		exp := "Table.Cases[2] testx_test.parseDigits(\"4a\") out[2]:\nexp <nil>\ngot not a digit"
+		if got := res.Checks()[8].Reason; got != exp {
+			t.Errorf("bad reason\nexp %s\ngot %s", exp, got)
+		}
+	})
+
+	t.Run("Outs", func(t *testing.T) {
+		res := testx.Table(parseDigits).Cases([]testx.Case{
+			{In: "a", Outs: map[int][]check.ValueChecker{
+				2: {check.Value.Is(errNotDigit)},
+				1: {check.Value.Is(0), check.Value.Is(1)}, // fail
+			}},
+		}).DryRun()
+
+		expChecks := []testx.CheckResult{
+			{Passed: true},
+			{Passed: false, Reason: "Table.Cases[0] testx_test.parseDigits(\"a\") out[1]:\nexp 1\ngot 0"},
+			{Passed: true},
+		}
+		assertEqualBaseResults(t, res, baseResults{
+			passed:  false,
+			failed:  true,
+			nPassed: 2,
+			nFailed: 1,
+			nChecks: 3,
+			checks:  expChecks,
+		})
+	})
+
+	t.Run("bad ExpAll length", func(t *testing.T) {
+		defer testutil.AssertPanicMessage(t,
+			"invalid Case: Cases[0]: ExpAll: exp 3 values "+
+				"(number of values returned by testx_test.parseDigits), got 2",
+		)
+		testx.Table(parseDigits).Cases([]testx.Case{
+			{In: "42", ExpAll: testx.Args{42, 2}},
+		}).DryRun()
+	})
+
+	t.Run("bad Outs position", func(t *testing.T) {
+		defer testutil.AssertPanicMessage(t,
+			"invalid Case: Cases[0]: Outs: exp 0 <= n < 3 "+
+				"(number of values returned by testx_test.parseDigits), got 3",
+		)
+		testx.Table(parseDigits).Cases([]testx.Case{
+			{In: "42", Outs: map[int][]check.ValueChecker{
+				3: {check.Value.Is(nil)},
+			}},
+		}).DryRun()
+	})
+}
+
+func TestTableRunnerCaseErrors(t *testing.T) {
+	t.Run("error expectations", func(t *testing.T) {
+		res := testx.Table(findUser).Cases([]testx.Case{
+			{In: 1, Exp: "alice"}, // implies no error
+			{In: 0, ErrIs: errNotFound},
+			{In: -1, ErrAs: new(*userIDError)},
+			{In: -1, ErrAs: new(interface{ Temporary() bool })}, // fail
+			{In: -1, ExpErr: "invalid user ID"},
+			{In: 0, Exp: ""},                   // fail: unexpected error
+			{In: 1, ErrIs: errNotFound},        // fail
+			{In: 0, ExpErr: "invalid user ID"}, // fail
+			{In: 0, Outs: map[int][]check.ValueChecker{1: {check.Value.NotZero()}}},
+		}).DryRun()
+
+		expPassed := []bool{true, true, true, false, true, false, false, false, true}
+		for i, exp := range expPassed {
+			if got := res.PassedAt(i); got != exp {
+				t.Errorf("case %d: exp passed == %v, got %v", i, exp, got)
+			}
+		}
+		if n := len(res.Cases()[0].Checks); n != 2 {
+			t.Errorf("exp implicit nil error check, got %d checks", n)
+		}
+		if n := len(res.Cases()[8].Checks); n != 1 {
+			t.Errorf("exp no implicit nil error check, got %d checks", n)
+		}
+
+		expReasons := map[int]string{
+			3: "Table.Cases[3] testx_test.findUser(-1) out[1]:\nexp error as interface { Temporary() bool }\ngot invalid user ID -1",
+			5: "Table.Cases[5] testx_test.findUser(0) out[1]:\nexp <nil>\ngot findUser(0): user not found",
+			6: "Table.Cases[6] testx_test.findUser(1) out[1]:\nexp error matching user not found\ngot <nil>",
+			7: "Table.Cases[7] testx_test.findUser(0) out[1]:\nexp error containing \"invalid user ID\"\ngot findUser(0): user not found",
+		}
+		for i, exp := range expReasons {
+			checks := res.Cases()[i].Checks
+			if got := checks[len(checks)-1].Reason; got != exp {
+				t.Errorf("case %d: bad reason\nexp %s\ngot %s", i, exp, got)
+			}
+		}
+	})
+
+	t.Run("no trailing error", func(t *testing.T) {
+		defer testutil.AssertPanicMessage(t,
+			"invalid Case: Cases[0]: error expectations set "+
+				"but testx_test.double does not return a trailing error",
+		)
+		testx.Table(double).Cases([]testx.Case{
+			{In: 0, ErrIs: errNotFound},
+		}).DryRun()
+	})
+
+	t.Run("bad ErrAs target", func(t *testing.T) {
+		defer testutil.AssertPanicMessage(t,
+			"invalid Case: Cases[0]: ErrAs: exp a non-nil pointer "+
+				"to an error or interface type, got *int",
+		)
+		testx.Table(findUser).Cases([]testx.Case{
+			{In: 0, ErrAs: new(int)},
+		}).DryRun()
+	})
+}
+
+func TestTableRunnerCasePanics(t *testing.T) {
+	res := testx.Table(mustPositive).Cases([]testx.Case{
+		{In: 1, Exp: 1},
+		{In: -1, Exp: -1}, // fail: unexpected panic
+		{In: -1, Panics: true},
+		{In: -1, PanicPass: []check.ValueChecker{check.Value.Is("negative number")}},
+		{In: -1, PanicPass: []check.ValueChecker{check.Value.Is("")}}, // fail
+		{In: 1, Panics: true}, // fail: no panic
+	}).DryRun()
+
+	expPassed := []bool{true, false, true, true, false, false}
+	for i, exp := range expPassed {
+		if got := res.PassedAt(i); got != exp {
+			t.Errorf("case %d: exp passed == %v, got %v", i, exp, got)
+		}
+	}
+
+	cases := res.Cases()
+	if got := cases[2].Panic; got != "negative number" {
+		t.Errorf("exp recovered value in CaseResult.Panic, got %v", got)
+	}
+	if cases[2].Outs != nil {
+		t.Errorf("exp nil outs after panic, got %v", cases[2].Outs)
+	}
+
+	unexpectedPanic := cases[1].Checks[0].Reason
+	expPrefix := "Table.Cases[1] testx_test.mustPositive(-1):\nexp no panic\ngot panic: negative number\n\ngoroutine"
+	if !strings.HasPrefix(unexpectedPanic, expPrefix) || !strings.Contains(unexpectedPanic, "mustPositive") {
+		t.Errorf("bad reason for unexpected panic:\n%s", unexpectedPanic)
+	}
+
+	for i, exp := range map[int]string{
+		4: "Table.Cases[4] testx_test.mustPositive(-1) panic:\nexp \ngot negative number",
+		5: "Table.Cases[5] testx_test.mustPositive(1):\nexp panic\ngot return values [1]",
+	} {
+		checks := cases[i].Checks
+		if got := checks[len(checks)-1].Reason; got != exp {
+			t.Errorf("case %d: bad reason\nexp %s\ngot %s", i, exp, got)
+		}
+	}
+}
+
+func TestMethod(t *testing.T) {
+	empty := &cache{}
+	filled := &cache{"k": 1}
+
+	t.Run("per-case receiver", func(t *testing.T) {
+		res := testx.Method((*cache)(nil), "Get").Cases([]testx.Case{
+			{Receiver: empty, In: "k", ExpAll: testx.Args{0, false}},
+			{Receiver: filled, In: "k", ExpAll: testx.Args{1, true}},
+			{Receiver: filled, In: "k", Exp: 2}, // fail
+		}).DryRun()
+
+		if !res.PassedAt(0) || !res.PassedAt(1) || res.PassedAt(2) {
+			t.Errorf("bad results: %v", res.Checks())
+		}
+		if got := res.Cases()[1].Receiver; got != filled {
+			t.Errorf("exp CaseResult.Receiver %v, got %v", filled, got)
+		}
+		if got := res.Cases()[1].Args; !deq(got, testx.Args{"k"}) {
+			t.Errorf("exp CaseResult.Args without receiver, got %v", got)
+		}
+		exp := "Table.Cases[2] (*testx_test.cache).Get(\"k\"):\nexp 2\ngot 1"
+		if got := res.Checks()[len(res.Checks())-1].Reason; got != exp {
+			t.Errorf("bad reason\nexp %s\ngot %s", exp, got)
+		}
+	})
+
+	t.Run("bound receiver", func(t *testing.T) {
+		testx.Method(filled, "Get").Cases([]testx.Case{
+			{In: "k", Exp: 1},
+			{In: "x", Exp: 0},
+			{Receiver: empty, In: "k", Exp: 0},
+		}).Run(t)
+	})
+
+	t.Run("pointer-holding receiver", func(t *testing.T) {
+		hits := 0
+		testx.Method(&store{items: cache{"a": 1}, hits: &hits}, "Get").Cases([]testx.Case{
+			{In: "a", Exp: 1},
+		}).Run(t)
+		// the receiver addresses are not part of the subtest name
+		assertSubtestRun(t, `Table.Cases[0] (*testx_test.store).Get("a")`)
+	})
+
+	t.Run("method with no parameters", func(t *testing.T) {
+		testx.Method(cache{}, "Len").Cases([]testx.Case{
+			{Exp: 0},
+			{Receiver: cache{"a": 0, "b": 0}, Exp: 2},
+		}).Run(t)
+	})
+
+	t.Run("bad receiver type", func(t *testing.T) {
+		defer testutil.AssertPanicMessage(t,
+			"invalid Case: Cases[0]: cannot call testx_test.(*cache).Get: "+
+				"invalid arguments: arg 0: exp type *testx_test.cache, got testx_test.cache (map[])",
+		)
+		testx.Method(empty, "Get").Cases([]testx.Case{
+			{Receiver: cache{}, In: "k", Exp: 0},
+		}).DryRun()
+	})
+
+	t.Run("bad method name", func(t *testing.T) {
+		defer testutil.AssertPanicMessage(t,
+			"Method(*testx_test.cache, Set): invalid Table func: no exported method with this name",
+		)
+		testx.Method(empty, "Set")
+	})
+
+	t.Run("receiver on non-method", func(t *testing.T) {
+		defer testutil.AssertPanicMessage(t,
+			"invalid Case: Cases[0]: Receiver: testx_test.double is not a method, use testx.Method",
+		)
+		testx.Table(double).Cases([]testx.Case{
+			{Receiver: empty, In: 1, Exp: 2},
+		}).DryRun()
+	})
+}
+
+func TestTableRunnerVariadic(t *testing.T) {
+	t.Run("variadic args", func(t *testing.T) {
+		res := testx.Table(join).Cases([]testx.Case{
+			{Args: testx.Args{","}, Exp: ""},
+			{Args: testx.Args{",", "a"}, Exp: "a"},
+			{Args: testx.Args{",", "a", "b", "c"}, Exp: "a,b,c"},
+			{Args: testx.Args{",", []string{"a", "b"}}, Exp: "a,b"},
+			{Args: testx.Args{",", "a", "b"}, Exp: "a;b"}, // fail
+		}).DryRun()
+
+		if n := res.NFailed(); n != 1 {
+			t.Errorf("exp 1 failed check, got %d", n)
+		}
+		exp := "Table.Cases[4] testx_test.join(\",\", \"a\", \"b\"):\nexp a;b\ngot a,b"
+		if got := res.Checks()[4].Reason; got != exp {
+			t.Errorf("bad reason\nexp %s\ngot %s", exp, got)
+		}
+	})
+
+	t.Run("variadic In", func(t *testing.T) {
+		testx.Table(join).Config(testx.TableConfig{
+			InPos:     1,
+			FixedArgs: testx.Args{"-"},
+		}).Cases([]testx.Case{
+			{In: "a", Exp: "a"},
+			{In: []string{"a", "b"}, Exp: "a-b"},
+		}).Run(t)
+	})
+
+	t.Run("bad variadic arg", func(t *testing.T) {
+		defer testutil.AssertPanicMessage(t,
+			"invalid Case: Cases[0]: cannot call testx_test.join: "+
+				"invalid arguments: arg 2: exp type string, got int (1)",
+		)
+		testx.Table(join).Cases([]testx.Case{
+			{Args: testx.Args{",", "a", 1}, Exp: ""},
+		}).DryRun()
+	})
+}
+
+func TestTableRunnerHooks(t *testing.T) {
+	var calls []string
+	record := func(format string, args ...interface{}) {
+		calls = append(calls, fmt.Sprintf(format, args...))
+	}
+
+	registry := map[string]int{}
+	lookup := func(k string) int {
+		record("call %s", k)
+		if k == "panic" {
+			panic("oops")
+		}
+		return registry[k]
+	}
+	setup := func(k string, v int) func() func() {
+		return func() func() {
+			record("setup %s", k)
+			registry[k] = v
+			return func() {
+				record("cleanup %s", k)
+				delete(registry, k)
+			}
+		}
+	}
+
+	res := testx.Table(lookup).Config(testx.TableConfig{
+		BeforeEach: func(i int, c testx.Case) { record("before %d %v", i, c.In) },
+		AfterEach:  func(i int, c testx.Case) { record("after %d %v", i, c.In) },
+	}).Cases([]testx.Case{
+		{In: "a", Exp: 1, Setup: setup("a", 1)},
+		{In: "a", Exp: 0},
+		{In: "panic", Panics: true, Setup: setup("panic", 0)},
+		{In: "b", Exp: -1, Setup: setup("b", 2)}, // fail
+	}).DryRun()
+
+	if !res.PassedAt(0) || !res.PassedAt(1) || !res.PassedAt(2) || res.PassedAt(3) {
+		t.Errorf("bad results: %v", res.Checks())
+	}
+
+	expCalls := []string{
+		"before 0 a", "setup a", "call a", "cleanup a", "after 0 a",
+		"before 1 a", "call a", "after 1 a",
+		"before 2 panic", "setup panic", "call panic", "cleanup panic", "after 2 panic",
+		"before 3 b", "setup b", "call b", "cleanup b", "after 3 b",
+	}
+	if !deq(calls, expCalls) {
+		t.Errorf("bad hooks calls\nexp %v\ngot %v", expCalls, calls)
+	}
+	if len(registry) != 0 {
+		t.Errorf("exp cleanups to be called, got registry %v", registry)
+	}
+}
+
+func TestTableRunnerHookPanics(t *testing.T) {
+	var called []int
+	record := func(n int) int {
+		called = append(called, n)
+		return n
+	}
+	panics := func() func() { panic("setup failure") }
+
+	res := testx.Table(record).Config(testx.TableConfig{
+		BeforeEach: func(i int, c testx.Case) {
+			if i == 1 {
+				panic("before failure")
+			}
+		},
+		AfterEach: func(i int, c testx.Case) {
+			if i == 2 {
+				panic("after failure")
+			}
+		},
+	}).Cases([]testx.Case{
+		{In: 0, Panics: true, Setup: panics},
+		{In: 1, Exp: 1},
+		{In: 2, Exp: 2},
+		{In: 3, Exp: 3, Setup: func() func() { return func() { panic("cleanup failure") } }},
+	}).DryRun()
+
+	for i, hook := range []string{"Setup", "BeforeEach", "AfterEach", "cleanup"} {
+		checks := res.Cases()[i].Checks
+		expPrefix := fmt.Sprintf("Table.Cases[%d] testx_test.TestTableRunnerHookPanics.func1(%d) %s:\nexp no panic\ngot panic: ", i, i, hook)
+		if len(checks) != 1 || checks[0].Passed || !strings.HasPrefix(checks[0].Reason, expPrefix) {
+			t.Errorf("case %d: exp %s panic to fail the case, got %v", i, hook, checks)
+		}
+	}
+	if exp := []int{2, 3}; !deq(called, exp) {
+		t.Errorf("exp calls %v, got %v", exp, called)
+	}
+}
+
+func TestTableRunnerTimeout(t *testing.T) {
+	block := make(chan struct{})
+	defer close(block)
+
+	wait := func(ms int) int {
+		if ms < 0 {
+			<-block
+		}
+		time.Sleep(time.Duration(ms) * time.Millisecond)
+		return ms
+	}
+
+	res := testx.Table(wait).Config(testx.TableConfig{
+		Timeout: 50 * time.Millisecond,
+	}).Cases([]testx.Case{
+		{In: 0, Exp: 0},
+		{In: -1, Exp: -1}, // fail: config timeout
+		{In: 100, Exp: 100, Timeout: time.Second},
+		{In: -1, Exp: -1, Timeout: 10 * time.Millisecond}, // fail: case timeout
+	}).DryRun()
+
+	if !res.PassedAt(0) || res.PassedAt(1) || !res.PassedAt(2) || res.PassedAt(3) {
+		t.Errorf("bad results: %v", res.Checks())
+	}
+
+	cases := res.Cases()
+	for i, exp := range []bool{false, true, false, true} {
+		if got := cases[i].TimedOut; got != exp {
+			t.Errorf("Cases[%d].TimedOut: exp %v, got %v", i, exp, got)
+		}
+	}
+
+	checks := cases[3].Checks
+	if len(checks) != 1 {
+		t.Fatalf("exp 1 check for timed out case, got %d", len(checks))
+	}
+	reason := checks[0].Reason
+	expPrefix := "Table.Cases[3] testx_test.TestTableRunnerTimeout.func1(-1):\n" +
+		"exp to return within 10ms\ngot timeout after "
+	if !strings.HasPrefix(reason, expPrefix) {
+		t.Errorf("bad reason\nexp prefix %q\ngot %q", expPrefix, reason)
+	}
+}
+
+func TestTableRunnerSkip(t *testing.T) {
+	var calls []int
+	f := func(n int) int {
+		calls = append(calls, n)
+		return n
+	}
+
+	t.Run("skip", func(t *testing.T) {
+		calls = nil
+		cases := []testx.Case{
+			{In: 0, Exp: 0},
+			{In: 1, Exp: -1, Skip: "flaky"}, // would fail
+			{In: 2, Exp: 2},
+		}
+
+		testx.Table(f).Cases(cases).Run(t)
+		if exp := []int{0, 2}; !reflect.DeepEqual(calls, exp) {
+			t.Errorf("bad calls\nexp %v\ngot %v", exp, calls)
+		}
+
+		calls = nil
+		res := testx.Table(f).Cases(cases).DryRun()
+		if !res.Passed() || res.Focused() || res.NSkipped() != 1 || res.NChecks() != 2 {
+			t.Errorf("bad results: %v", res.Checks())
+		}
+		if cr := res.Cases()[1]; !cr.Skipped || cr.Skip != "flaky" || len(cr.Checks) != 0 {
+			t.Errorf("bad skipped case result: %+v", cr)
+		}
+		if exp := []int{0, 2}; !reflect.DeepEqual(calls, exp) {
+			t.Errorf("bad calls\nexp %v\ngot %v", exp, calls)
+		}
+	})
+
+	t.Run("only", func(t *testing.T) {
+		calls = nil
+		res := testx.Table(f).Cases([]testx.Case{
+			{In: 0, Exp: 0},
+			{In: 1, Exp: 1, Only: true},
+			{In: 2, Exp: 2, Only: true, Skip: "flaky"},
+			{In: 3, Exp: 3},
+		}).DryRun()
+
+		if exp := []int{1}; !reflect.DeepEqual(calls, exp) {
+			t.Errorf("bad calls\nexp %v\ngot %v", exp, calls)
+		}
+		if !res.Focused() || res.Passed() || res.NSkipped() != 3 || !res.PassedAt(1) {
+			t.Errorf("bad results: %v", res.Checks())
+		}
+		if cr := res.Cases()[0]; !cr.Skipped || !strings.Contains(cr.Skip, "Case.Only") {
+			t.Errorf("bad skipped case result: %+v", cr)
+		}
+
+		checks := res.Checks()
+		expReason := "Table: Case.Only is set on 2 of 4 cases, remove it to run all cases"
+		if last := checks[len(checks)-1]; last.Passed || last.Reason != expReason {
+			t.Errorf("bad focus check\nexp %q\ngot %v", expReason, last)
+		}
+	})
+}
+
+func TestTableRunnerOracle(t *testing.T) {
+	// sumTo is the reference implementation of fastSumTo.
+	sumTo := func(n int) int {
+		sum := 0
+		for i := 1; i <= n; i++ {
+			sum += i
+		}
+		return sum
+	}
+
+	t.Run("same outputs", func(t *testing.T) {
+		testx.Diff(fastSumTo, sumTo).Cases([]testx.Case{
+			{In: 0}, {In: 1}, {In: 10}, {In: 100, Exp: 5050},
+		}).Run(t)
+	})
+
+	t.Run("different outputs", func(t *testing.T) {
+		res := testx.Table(fastSumTo).Oracle(sumTo).Cases([]testx.Case{
+			{In: 10},
+			{In: -1},         // fail: oracle
+			{In: -4, Exp: 6}, // fail: Exp and oracle
+		}).DryRun()
+
+		if !res.PassedAt(0) || res.PassedAt(1) || res.PassedAt(2) || res.NFailed() != 3 {
+			t.Errorf("bad results: %v", res.Checks())
+		}
+
+		exp := "Table.Cases[1] testx_test.fastSumTo(-1) out[0] " +
+			"vs testx_test.TestTableRunnerOracle.func1:\nexp 0\ngot 1"
+		if got := res.Cases()[1].Checks[0].Reason; got != exp {
+			t.Errorf("bad reason\nexp %q\ngot %q", exp, got)
+		}
+	})
+
+	t.Run("oracle panic", func(t *testing.T) {
+		res := testx.Diff(parseDigits, mustParseDigits).Cases([]testx.Case{
+			{In: "42"},
+			{In: "4x"}, // fail: oracle panics
+		}).DryRun()
+
+		if !res.PassedAt(0) || res.PassedAt(1) {
+			t.Errorf("bad results: %v", res.Checks())
+		}
+		checks := res.Cases()[1].Checks
+		if len(checks) != 1 || !strings.Contains(checks[0].Reason, "testx_test.mustParseDigits panic") {
+			t.Errorf("bad checks: %v", checks)
+		}
+	})
+
+	t.Run("mutated inputs", func(t *testing.T) {
+		// reverseInts sorts in place only the inputs already sorted
+		// in reverse order
+		reverseInts := func(s []int) []int {
+			for i, j := 0, len(s)-1; i < j; i, j = i+1, j-1 {
+				s[i], s[j] = s[j], s[i]
+			}
+			return s
+		}
+		sortInts := func(s []int) []int {
+			sort.Ints(s)
+			return s
+		}
+
+		in := []int{3, 1, 2}
+		res := testx.Diff(reverseInts, sortInts).Cases([]testx.Case{
+			{In: []int{3, 2, 1}},
+			{In: in}, // fail
+		}).DryRun()
+
+		if !res.PassedAt(0) || res.PassedAt(1) {
+			t.Errorf("bad results: %v", res.Checks())
+		}
+		if !deq(in, []int{3, 1, 2}) {
+			t.Errorf("exp case inputs not to be altered, got %v", in)
+		}
+	})
+
+	t.Run("incompatible oracle", func(t *testing.T) {
+		for _, ref := range []interface{}{
+			nil,
+			func(int) int64 { return 0 },
+			func(int, int) int { return 0 },
+			func(...int) int { return 0 },
+		} {
+			func() {
+				defer testutil.AssertPanic(t)
+				testx.Table(fastSumTo).Oracle(ref)
+			}()
+		}
+	})
+}
+
+func TestTableRunnerCombine(t *testing.T) {
+	expectClamp := func(args testx.Args) testx.Case {
+		v, lo, hi := args[0].(int), args[1].(int), args[2].(int)
+		if lo > hi {
+			return testx.Case{Panics: true}
+		}
+		c := testx.Case{Pass: []check.ValueChecker{
+			checkconv.FromInt(check.Int.InRange(lo, hi)),
+		}}
+		if v < lo || v > hi {
+			c.Not = []interface{}{v}
+		}
+		return c
+	}
+	values := []testx.Args{{-5, 0, 5, 10}, {0, 3}, {3, 8}}
+
+	t.Run("cartesian product", func(t *testing.T) {
+		res := testx.Table(mustClamp).Combine(expectClamp, values...).DryRun()
+		cases := res.Cases()
+		if n := len(cases); n != 16 {
+			t.Fatalf("exp 16 cases, got %d", n)
+		}
+		if !res.Passed() {
+			t.Errorf("exp all cases to pass, got %v", res.Checks())
+		}
+		if exp := (testx.Args{-5, 0, 3}); !reflect.DeepEqual(cases[0].Args, exp) {
+			t.Errorf("bad args\nexp %v\ngot %v", exp, cases[0].Args)
+		}
+		if exp := (testx.Args{10, 3, 8}); !reflect.DeepEqual(cases[15].Args, exp) {
+			t.Errorf("bad args\nexp %v\ngot %v", exp, cases[15].Args)
+		}
+
+		testx.Table(mustClamp).Combine(expectClamp, values...).Run(t)
+		assertSubtestRun(t, "Table.Cases[15] testx_test.mustClamp(10, 3, 8)")
+	})
+
+	t.Run("pairwise", func(t *testing.T) {
+		res := testx.Table(mustClamp).CombinePairwise(expectClamp, values...).DryRun()
+		if n := len(res.Cases()); n >= 16 || n < 8 {
+			t.Errorf("exp 8 <= cases < 16, got %d", n)
+		}
+		if !res.Passed() {
+			t.Errorf("exp all cases to pass, got %v", res.Checks())
+		}
+	})
+
+	t.Run("nil expect", func(t *testing.T) {
+		res := testx.Table(parseDigits).Combine(nil, testx.Args{"1", "a", "42"}).DryRun()
+		if !res.PassedAt(0) || res.PassedAt(1) || !res.PassedAt(2) {
+			t.Errorf("bad results: %v", res.Checks())
+		}
+	})
+
+	t.Run("empty values", func(t *testing.T) {
+		defer testutil.AssertPanicMessage(t,
+			"invalid Case: CombinePairwise: values[1]: exp at least 1 candidate value "+
+				"for parameter 1 of testx_test.mustClamp, got none",
+		)
+		testx.Table(mustClamp).CombinePairwise(expectClamp, testx.Args{1}, testx.Args{}, testx.Args{2})
+	})
+}
+
+func TestTableRunnerExpect(t *testing.T) {
+	// expectInRange expects clamp(v, lo, hi) to be in [lo, hi],
+	// and to be v if v is in range.
+	expectInRange := func(in ...interface{}) []check.ValueChecker {
+		v, lo, hi := in[0].(int), in[1].(int), in[2].(int)
+		checkers := []check.ValueChecker{checkconv.FromInt(check.Int.InRange(lo, hi))}
+		if v >= lo && v <= hi {
+			checkers = append(checkers, check.Value.Is(v))
+		}
+		return checkers
+	}
+
+	t.Run("config expect", func(t *testing.T) {
+		res := testx.Table(clamp).Config(testx.TableConfig{
+			Expect: expectInRange,
+		}).Cases([]testx.Case{
+			{Args: testx.Args{-1, 0, 10}},
+			{Args: testx.Args{5, 0, 10}},
+			{Args: testx.Args{5, 10, 0}}, // fail: bad range
+			{Args: testx.Args{42, 0, 10}, Exp: 10},
+		}).DryRun()
+
+		if !res.PassedAt(0) || !res.PassedAt(1) || res.PassedAt(2) || !res.PassedAt(3) {
+			t.Errorf("bad results: %v", res.Checks())
+		}
+		if n := len(res.Cases()[1].Checks); n != 2 {
+			t.Errorf("exp 2 checks from Expect, got %d", n)
+		}
+		if n := len(res.Cases()[3].Checks); n != 2 {
+			t.Errorf("exp Exp and Expect checks, got %d checks", n)
+		}
+	})
+
+	t.Run("case expect", func(t *testing.T) {
+		isZero := func(in ...interface{}) []check.ValueChecker {
+			return []check.ValueChecker{check.Value.Is(0)}
+		}
+		res := testx.Table(clamp).Config(testx.TableConfig{
+			Expect: expectInRange,
+		}).Cases([]testx.Case{
+			{Args: testx.Args{-1, 0, 10}, Expect: isZero},
+			{Args: testx.Args{5, 0, 10}, Expect: isZero}, // fail
+		}).DryRun()
+
+		if !res.PassedAt(0) || res.PassedAt(1) || res.NChecks() != 2 {
+			t.Errorf("bad results: %v", res.Checks())
+		}
+	})
+
+	t.Run("with generated cases", func(t *testing.T) {
+		testx.Table(clamp).Config(testx.TableConfig{
+			Expect: expectInRange,
+		}).Combine(nil, testx.Args{-5, 0, 5, 10}, testx.Args{0, 3}, testx.Args{3, 8}).Run(t)
+	})
+
+	t.Run("error output", func(t *testing.T) {
+		// Expect checks the OutPos value only, so a nil error is still expected
+		res := testx.Table(parseDigits).Config(testx.TableConfig{
+			OutPos: 1,
+			Expect: func(in ...interface{}) []check.ValueChecker {
+				return []check.ValueChecker{check.Value.Is(len(in[0].(string)))}
+			},
+		}).Cases([]testx.Case{{In: "12"}, {In: "1a"}}).DryRun()
+
+		if !res.PassedAt(0) || res.PassedAt(1) || res.NChecks() != 4 {
+			t.Errorf("bad results: %v", res.Checks())
+		}
+	})
+}
+
+func TestTableRunnerBench(t *testing.T) {
+	// the cases below are never timed, so testing.Benchmark returns
+	// without running to the default benchtime. The timed path
+	// is covered by BenchmarkTableRunner.
+	calls := map[int]int{}
+	f := func(n int) int {
+		calls[n]++
+		return n
+	}
+
+	testing.Benchmark(func(b *testing.B) {
+		testx.Table(f).Config(testx.TableConfig{BenchVerify: true}).Cases([]testx.Case{
+			{In: 1, Exp: 1, Skip: "skipped"},
+			{In: 2, Panics: true},
+			{In: 3, Exp: -1}, // fail
+		}).Bench(b)
+	})
+
+	if calls[1] != 0 || calls[2] != 0 {
+		t.Errorf("exp cases 1 and 2 to be skipped, got calls %v", calls)
+	}
+	if calls[3] != 1 {
+		t.Errorf("exp failing case 3 to be verified only, got %d calls", calls[3])
+	}
+
+	// a hook panic is recovered and fails the case before any timed call
+	testing.Benchmark(func(b *testing.B) {
+		testx.Table(f).Cases([]testx.Case{
+			{In: 4, Exp: 4, Setup: func() func() { panic("setup failure") }},
+		}).Bench(b)
+	})
+	if calls[4] != 0 {
+		t.Errorf("exp case 4 not to be benchmarked after a Setup panic, got %d calls", calls[4])
+	}
+}
+
+func BenchmarkTableRunner(b *testing.B) {
+	calls, verifications := 0, 0
+	f := func(v, lo, hi int) int {
+		calls++
+		return clamp(v, lo, hi)
+	}
+	verified := check.Value.Custom("verified", func(interface{}) bool {
+		verifications++
+		return true
+	})
+
+	testx.Table(f).Config(testx.TableConfig{BenchVerify: true}).Cases([]testx.Case{
+		{Args: testx.Args{-1, 0, 10}, Exp: 0, Pass: []check.ValueChecker{verified}},
+		{Args: testx.Args{5, 0, 10}, Exp: 5, Pass: []check.ValueChecker{verified}},
+		{Args: testx.Args{42, 0, 10}, Exp: 10, Pass: []check.ValueChecker{verified}},
+	}).Bench(b)
+
+	// each case is verified once, then called at least once timed
+	if verifications != 3 || calls < 6 {
+		b.Errorf("exp 3 verifications and 6+ calls, got %d and %d", verifications, calls)
+	}
+}
+
 func TestExpNil(t *testing.T) {
 	t.Run("Exp=ExpNil expects nil", func(t *testing.T) {
 		f := func(int) interface{} { return nil }
@@ -245,6 +1128,65 @@ func TestTableRunnerResults(t *testing.T) {
 	})
 }
 
+func TestTableRunnerCaseResults(t *testing.T) {
+	res := testx.Table(double).Cases([]testx.Case{
+		{
+			In:   21,
+			Exp:  42,
+			Not:  []interface{}{0},
+			Pass: checkconv.AssertMany(check.Int.GT(0), check.Int.LT(40)), // fail
+			Lab:  "many checks",
+		},
+		{In: 1, Exp: 2, Lab: "single check"},
+		{In: 2, Lab: "no checks"},
+	}).DryRun()
+
+	if n := res.NChecks(); n != 5 {
+		t.Errorf("exp 5 checks, got %d", n)
+	}
+
+	cases := res.Cases()
+	if len(cases) != 3 {
+		t.Fatalf("exp 3 case results, got %d", len(cases))
+	}
+
+	exp := testx.CaseResult{
+		Index: 0,
+		Label: "many checks",
+		Args:  testx.Args{21},
+		Outs:  []interface{}{42},
+	}
+	got := cases[0]
+	if got.Index != exp.Index || got.Label != exp.Label ||
+		!deq(got.Args, exp.Args) || !deq(got.Outs, exp.Outs) {
+		failBadResults(t, "Cases()[0]", got, exp)
+	}
+	if n := len(got.Checks); n != 4 {
+		t.Errorf("exp 4 checks for case 0, got %d", n)
+	}
+
+	assertEqualTableResults(t, res, tableResults{
+		baseResults: baseResults{
+			passed:  false,
+			failed:  true,
+			nPassed: 4,
+			nFailed: 1,
+			nChecks: 5,
+			checks: []testx.CheckResult{
+				{Passed: true},
+				{Passed: true},
+				{Passed: true},
+				{Passed: false, Reason: "many checks:\nexp < 40\ngot 42"},
+				{Passed: true},
+			},
+		},
+		passedAt:    map[int]bool{0: false, 1: true, 2: true},
+		failedAt:    map[int]bool{0: true, 1: false, 2: false},
+		passedLabel: map[string]bool{"many checks": false, "single check": true, "no checks": true},
+		failedLabel: map[string]bool{"many checks": true, "single check": false, "no checks": false},
+	})
+}
+
 // Tested funcs
 
 func evenSingle(a1 int) bool {
@@ -269,6 +1211,112 @@ func double(n int) int {
 	return 2 * n
 }
 
+func clamp(v, lo, hi int) int {
+	switch {
+	case v < lo:
+		return lo
+	case v > hi:
+		return hi
+	default:
+		return v
+	}
+}
+
+var errNotDigit = errors.New("not a digit")
+
+// parseDigits parses the leading digits of s. It returns the parsed number,
+// the number of consumed bytes and errNotDigit if s contains
+// a non-digit character.
+func parseDigits(s string) (int, int, error) {
+	n := 0
+	for i, c := range s {
+		if c < '0' || c > '9' {
+			return n, i, errNotDigit
+		}
+		n = 10*n + int(c-'0')
+	}
+	return n, len(s), nil
+}
+
+// mustClamp is a version of clamp that panics if lo > hi.
+func mustClamp(v, lo, hi int) int {
+	if lo > hi {
+		panic("lo > hi")
+	}
+	return clamp(v, lo, hi)
+}
+
+// mustParseDigits is a version of parseDigits that panics
+// instead of returning an error.
+func mustParseDigits(s string) (int, int, error) {
+	n, i, err := parseDigits(s)
+	if err != nil {
+		panic(err)
+	}
+	return n, i, nil
+}
+
+// fastSumTo returns the sum of the integers from 1 to n,
+// or 1 if n < 0 (bug).
+func fastSumTo(n int) int {
+	if n < 0 {
+		return 1
+	}
+	return n * (n + 1) / 2
+}
+
+var errNotFound = errors.New("user not found")
+
+type userIDError struct{ id int }
+
+func (err *userIDError) Error() string {
+	return fmt.Sprintf("invalid user ID %d", err.id)
+}
+
+func findUser(id int) (string, error) {
+	switch {
+	case id < 0:
+		return "", &userIDError{id: id}
+	case id == 1:
+		return "alice", nil
+	default:
+		return "", fmt.Errorf("findUser(%d): %w", id, errNotFound)
+	}
+}
+
+func mustPositive(n int) int {
+	if n < 0 {
+		panic("negative number")
+	}
+	return n
+}
+
+type cache map[string]int
+
+func (c *cache) Get(k string) (int, bool) {
+	v, ok := (*c)[k]
+	return v, ok
+}
+
+func (c cache) Len() int {
+	return len(c)
+}
+
+// store is a cache counting its hits, used to test method labels.
+type store struct {
+	items cache
+	hits  *int
+}
+
+func (s *store) Get(k string) int {
+	*s.hits++
+	return s.items[k]
+}
+
+func join(sep string, parts ...string) string {
+	return strings.Join(parts, sep)
+}
+
 func divide(a, b float64) (float64, error) {
 	if b == 0 {
 		return 0, errors.New("division by 0")
@@ -278,6 +1326,18 @@ func divide(a, b float64) (float64, error) {
 
 // Helpers
 
+// assertSubtestRun fails t if no subtest with the given name was run
+// by t. It relies on the fact that package testing deduplicates
+// subtests names by appending a suffix "#01" to the second occurrence.
+func assertSubtestRun(t *testing.T, name string) {
+	t.Helper()
+	var gotName string
+	t.Run(name, func(t *testing.T) { gotName = t.Name() })
+	if !strings.HasSuffix(gotName, "#01") {
+		t.Errorf("exp subtest %q to be run, got none", name)
+	}
+}
+
 func panicOnUnexpectedArgs(a0 []byte, a2 map[rune][][]float64) {
 	deq := reflect.DeepEqual
 	if !deq(a0, expFixedArgs["a0"]) || !deq(a2, expFixedArgs["a2"]) {
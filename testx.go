@@ -2,7 +2,9 @@ package testx
 
 import (
 	"fmt"
+	"io"
 	"net/http"
+	"net/url"
 	"testing"
 	"time"
 
@@ -36,6 +38,98 @@ type ValueRunner interface {
 	Pass(checkers ...check.ValueChecker) ValueRunner
 }
 
+// CallRunner provides methods to perform tests on a single call
+// to a func: its return values, its panic and its duration.
+type CallRunner interface {
+	Runner
+	// DryRun returns a CallResulter to access test results
+	// without running *testing.T.
+	DryRun() CallResulter
+	// Out adds checkers on the value returned at position pos.
+	// It panics if pos is out of range.
+	Out(pos int, checkers ...check.ValueChecker) CallRunner
+	// Err adds checkers on the trailing error returned by the func.
+	// It panics if the func does not return a trailing error.
+	Err(checkers ...check.ValueChecker) CallRunner
+	// Panics sets the func to be expected to panic, and adds checkers
+	// on the recovered value. If so, checks added via Out and Err
+	// are not performed.
+	Panics(checkers ...check.ValueChecker) CallRunner
+	// NotPanics adds a check expecting the func not to panic,
+	// overriding a previous call to Panics.
+	// Regardless of it, an unexpected panic is always recovered
+	// and reported as a failed check.
+	NotPanics() CallRunner
+	// Duration adds checkers on the func's execution time.
+	// If Repeat is set, they are run on the first timed call.
+	Duration(checkers ...check.DurationChecker) CallRunner
+	// DurationStats adds checkers on the execution times of the timed
+	// calls set via Repeat, such as check.DurationStats.P95.
+	DurationStats(checkers ...check.DurationStatsChecker) CallRunner
+	// Repeat sets the number of timed calls to the func, collecting
+	// a duration sample for each one. Default is 1. Checks other than
+	// DurationStats are run on the first timed call, except a panic
+	// in any of the calls, warm-up included, is the panic of the run.
+	// It panics if n < 1.
+	Repeat(n int) CallRunner
+	// Warmup sets a number of untimed calls to the func before
+	// the timed ones. Default is 0. It panics if k < 0.
+	Warmup(k int) CallRunner
+	// NoGoroutineLeaks adds a check expecting the goroutines started
+	// during the call to be terminated after it, with a grace period
+	// of 100ms. Runtime and testing goroutines are ignored.
+	NoGoroutineLeaks() CallRunner
+	// Allocs adds checkers on the average number of allocations
+	// of a call, as measured by testing.AllocsPerRun over 100 extra calls
+	// with the same args. The overhead of calling the func via reflection
+	// is excluded. The checks are not performed if the func panics.
+	// The extra calls repeat the side effects of the func: if one of them
+	// panics, a single failing check is reported instead.
+	Allocs(checkers ...check.IntChecker) CallRunner
+	// AllocBytes adds checkers on the average number of bytes allocated
+	// by a call, measured like Allocs via runtime.MemStats. The measure
+	// is approximate as it includes concurrent allocations.
+	AllocBytes(checkers ...check.IntChecker) CallRunner
+}
+
+// PollRunner provides methods to run checks on a value that changes
+// over time, by polling it until a timeout.
+type PollRunner interface {
+	Runner
+	// DryRun returns a PollResulter to access test results
+	// without running *testing.T.
+	DryRun() PollResulter
+	// Timeout sets the duration of the polling window. Default is 1s.
+	Timeout(d time.Duration) PollRunner
+	// Interval sets the duration between two polls. Default is 10ms.
+	Interval(d time.Duration) PollRunner
+}
+
+// ChanRunner provides methods to run checks on the values received
+// from a channel. The checks consume the channel in the order
+// they are added.
+type ChanRunner interface {
+	Runner
+	// DryRun returns a ChanResulter to access test results
+	// without running *testing.T.
+	DryRun() ChanResulter
+	// Receives adds a check expecting n values to be received
+	// within the given duration.
+	Receives(n int, within time.Duration) ChanRunner
+	// ReceivesInOrder adds a check expecting the given values to be
+	// the next ones received, in order, within 1s.
+	ReceivesInOrder(values ...interface{}) ChanRunner
+	// EachReceived adds checkers that every received value is expected
+	// to pass, regardless of the check it was received by.
+	EachReceived(checkers ...check.ValueChecker) ChanRunner
+	// Closes adds a check expecting the channel to be closed within
+	// the given duration. Values received in the meantime are allowed.
+	Closes(within time.Duration) ChanRunner
+	// NeverReceives adds a check expecting no value to be received
+	// for the given duration.
+	NeverReceives(d time.Duration) ChanRunner
+}
+
 // TableRunner provides methods to run a series of test cases
 // on a single function.
 type TableRunner interface {
@@ -47,7 +141,65 @@ type TableRunner interface {
 	// parameters or multiple return values.
 	Config(cfg TableConfig) TableRunner
 	// Cases adds test cases to be run on the tested func.
+	// Each case is run in its own subtest, named after Case.Lab
+	// or the default case label if not set.
 	Cases(cases []Case) TableRunner
+	// CasesFromFile adds test cases decoded from the JSON file at path.
+	// See CasesFromReader for the expected format.
+	CasesFromFile(path string) TableRunner
+	// CasesFromReader adds test cases decoded from a JSON array read
+	// from r, each element having the following format:
+	//	{"lab": "label", "in": 42, "exp": true, "not": [false]}
+	// Values "in", "exp" and "not" are converted to the type of the
+	// tested func parameter at TableConfig.InPos and return value
+	// at TableConfig.OutPos when the cases are run, so Config can be
+	// called before or after. An explicit null value for "exp" expects
+	// a nil value. It panics if the input cannot be decoded, and the run
+	// panics if the values cannot be converted.
+	CasesFromReader(r io.Reader) TableRunner
+	// Bench runs each test case as a sub-benchmark of b named after
+	// the case, calling the tested func b.N times with the case args
+	// and reporting allocations. Cases expecting a panic are skipped.
+	// The measures include the overhead of calling a func via reflection.
+	// The hooks of TableConfig and Case.Setup are called around each round
+	// of b.N calls, outside of the timed section; a panic in one of them
+	// fails the case. Set TableConfig.BenchVerify to run the case checks
+	// once before timing.
+	Bench(b *testing.B)
+	// Parallel signals that the test cases are to be run in parallel
+	// with each other by calling t.Parallel in each case subtest.
+	Parallel() TableRunner
+	// Combine adds a test case for each combination of the given values
+	// (cartesian product), values[i] listing the candidate values for
+	// the ith argument of the tested func. Each case is built by expect
+	// from its arguments, then its Args are set to the combination,
+	// so the default case labels list the chosen values.
+	// expect may be nil if the cases have no expectations other than
+	// the default ones (e.g. a nil error, or an Oracle).
+	// It panics if any values[i] is empty.
+	//
+	// 	testx.Table(strings.Repeat).Combine(func(args testx.Args) testx.Case {
+	// 		n := len(args[0].(string)) * args[1].(int)
+	// 		return testx.Case{Pass: []check.ValueChecker{
+	// 			checkconv.FromString(check.String.Len(check.Int.Is(n))),
+	// 		}}
+	// 	}, testx.Args{"", "a", "abc"}, testx.Args{0, 1, 5})
+	Combine(expect func(args Args) Case, values ...Args) TableRunner
+	// CombinePairwise is like Combine, but only adds the cases needed
+	// for every pair of values of any two arguments to be tested at least
+	// once (all-pairs testing). It is Combine for less than 3 arguments.
+	CombinePairwise(expect func(args Args) Case, values ...Args) TableRunner
+	// Oracle sets a reference func the tested func is compared to:
+	// for each case, both are called with the same arguments
+	// and each value returned by the tested func is expected to equal
+	// the one returned by ref at the same position, in addition to
+	// the case expectations. ref must have the same type as the tested
+	// func, including the receiver as first parameter for a method.
+	// Each func is called with its own deep copy of the arguments,
+	// so one mutating its inputs does not alter the other's. Unexported
+	// struct fields are copied shallowly.
+	// It panics if ref is not a func of a compatible type.
+	Oracle(ref interface{}) TableRunner
 }
 
 // HTTPHandlerRunner provides methods to run tests on http handlers
@@ -67,7 +219,132 @@ type HTTPHandlerRunner interface {
 	// Response adds checkers on the written response.
 	Response(...check.HTTPResponseChecker) HTTPHandlerRunner
 	// Duration adds checkers on the handler's execution time;
+	// if Repeat is set, they are run on the first timed call.
 	Duration(...check.DurationChecker) HTTPHandlerRunner
+	// DurationStats adds checkers on the handler's execution times
+	// of the timed calls set via Repeat, such as check.DurationStats.P95.
+	DurationStats(...check.DurationStatsChecker) HTTPHandlerRunner
+	// Repeat sets the number of timed calls to the handler, collecting
+	// a duration sample for each one. Default is 1. The extra calls
+	// are made with a copy of the request and a new recorder; other checks
+	// are run on the first one. It panics if n < 1.
+	Repeat(n int) HTTPHandlerRunner
+	// Warmup sets a number of untimed calls to the handler before
+	// the timed ones, made like the extra calls of Repeat.
+	// Default is 0. It panics if k < 0.
+	Warmup(k int) HTTPHandlerRunner
+	// NoGoroutineLeaks adds a check expecting the goroutines started
+	// by the handler and middlewares to be terminated after they return,
+	// with a grace period of 100ms. Runtime and testing goroutines
+	// are ignored.
+	NoGoroutineLeaks() HTTPHandlerRunner
+	// Allocs adds checkers on the average number of allocations
+	// of the handler and middlewares to serve the request, as measured
+	// by testing.AllocsPerRun over 100 extra calls with a copy of it.
+	// The allocations of the recorder and the copied request are excluded.
+	Allocs(...check.IntChecker) HTTPHandlerRunner
+	// AllocBytes adds checkers on the average number of bytes allocated
+	// by the handler and middlewares, measured like Allocs
+	// via runtime.MemStats. The measure is approximate as it includes
+	// concurrent allocations.
+	AllocBytes(...check.IntChecker) HTTPHandlerRunner
+}
+
+// HTTPServerRunner provides methods to run tests on http handlers
+// served over a real connection by an httptest.Server.
+type HTTPServerRunner interface {
+	Runner
+	// DryRun returns a HTTPServerResulter to access test results
+	// without running *testing.T.
+	DryRun() HTTPServerResulter
+	// WithRequest sets the request sent by the client. Its URL scheme
+	// and host are replaced by the ones of the server, and its Host
+	// field is reset. If not set, the following default request is used:
+	//	http.NewRequest("GET", "/", nil)
+	WithRequest(*http.Request) HTTPServerRunner
+	// Response adds checkers on the response received by the client.
+	// Its body is fully read before the checks.
+	Response(...check.HTTPResponseChecker) HTTPServerRunner
+	// Duration adds checkers on the round trip time, from sending
+	// the request to reading the whole response body.
+	Duration(...check.DurationChecker) HTTPServerRunner
+	// FirstByte adds checkers on the time to receive the first byte
+	// of the response, for instance to check a handler flushes
+	// before returning.
+	FirstByte(...check.DurationChecker) HTTPServerRunner
+	// Proto adds checkers on the protocol of the response,
+	// such as "HTTP/1.1" or "HTTP/2.0".
+	Proto(...check.StringChecker) HTTPServerRunner
+	// TransferEncoding adds checkers on the transfer encodings
+	// of the response, such as []string{"chunked"}.
+	TransferEncoding(...check.ValueChecker) HTTPServerRunner
+	// KeepAlive adds a check expecting the connection to be reused
+	// by a second identical request.
+	KeepAlive() HTTPServerRunner
+	// Err adds checkers on the error returned by the client, for instance
+	// when the server times out or the handler hijacks the connection.
+	// If set, the checks on the response are not performed on error.
+	// If not, an error is reported as a failed check.
+	Err(...check.ValueChecker) HTTPServerRunner
+}
+
+// HTTPClientRunner provides methods to run tests on the requests
+// sent by a http client to a stub server.
+type HTTPClientRunner interface {
+	Runner
+	// DryRun returns a HTTPClientResulter to access test results
+	// without running *testing.T.
+	DryRun() HTTPClientResulter
+	// Respond sets the responses written by the stub server, in order.
+	// The last one is repeated for extra requests. If not set,
+	// the server responds with an empty 200 response.
+	Respond(responses ...StubResponse) HTTPClientRunner
+	// NRequests adds checkers on the number of captured requests.
+	NRequests(checkers ...check.IntChecker) HTTPClientRunner
+	// Request adds checkers on the ith captured request.
+	// The checks fail if less than i+1 requests were sent.
+	Request(i int, checkers ...check.HTTPRequestChecker) HTTPClientRunner
+	// EachRequest adds checkers on every captured request.
+	// A failing check reports the first request not passing.
+	EachRequest(checkers ...check.HTTPRequestChecker) HTTPClientRunner
+	// Err adds checkers on the error returned by the tested func.
+	// If not set, an error is reported as a failed check.
+	Err(checkers ...check.ValueChecker) HTTPClientRunner
+}
+
+// RequestBuilder provides methods to build a *http.Request
+// to be passed to WithRequest.
+type RequestBuilder interface {
+	// Query adds values for key to the URL query.
+	Query(key string, values ...string) RequestBuilder
+	// Header adds values for key to the header.
+	Header(key string, values ...string) RequestBuilder
+	// Cookie adds cookies to the header.
+	Cookie(cookies ...*http.Cookie) RequestBuilder
+	// ContextValue sets a value for key in the request context.
+	ContextValue(key, val interface{}) RequestBuilder
+	// BasicAuth sets the Authorization header for basic authentication.
+	BasicAuth(username, password string) RequestBuilder
+	// BearerAuth sets the Authorization header to "Bearer <token>".
+	BearerAuth(token string) RequestBuilder
+	// Body sets the body and Content-Type header.
+	// Setting a body overrides the previous one.
+	Body(body []byte, contentType string) RequestBuilder
+	// JSON sets the body to the JSON encoding of v
+	// and Content-Type to application/json.
+	JSON(v interface{}) RequestBuilder
+	// Form sets the body to the URL encoding of values
+	// and Content-Type to application/x-www-form-urlencoded.
+	Form(values url.Values) RequestBuilder
+	// Multipart sets the body to a multipart form with the given fields,
+	// written in keys order, then the given files, and Content-Type
+	// to multipart/form-data with its boundary.
+	Multipart(fields url.Values, files ...RequestFile) RequestBuilder
+	// Build returns a new request via httptest.NewRequest with the set
+	// values. It can be called several times, each request having its own
+	// body. It panics if a body could not be encoded or if the target
+	// is an invalid URL.
+	Build() *http.Request
 }
 
 /*
@@ -104,19 +381,117 @@ type HandlerResulter interface {
 	ResponseBody() []byte
 	// ResponseDuration returns the handler's execution time.
 	ResponseDuration() time.Duration
+	// ResponseDurations returns the handler's execution times
+	// of the timed calls set via Repeat.
+	ResponseDurations() []time.Duration
+	// Allocs returns the average number of allocations
+	// of the handler, if measured.
+	Allocs() int
+	// AllocBytes returns the average number of bytes allocated
+	// by the handler, if measured.
+	AllocBytes() int
+}
+
+// HTTPServerResulter provides methods to read HTTPServerRunner results
+// after a dry run.
+type HTTPServerResulter interface {
+	Resulter
+	// ResponseHeader returns the gotten response header.
+	ResponseHeader() http.Header
+	// ResponseStatus returns the gotten response status.
+	ResponseStatus() string
+	// ResponseCode returns the gotten response code.
+	ResponseCode() int
+	// ResponseBody returns the gotten response body.
+	ResponseBody() []byte
+	// ResponseDuration returns the round trip time.
+	ResponseDuration() time.Duration
+	// FirstByte returns the time to receive the first response byte.
+	FirstByte() time.Duration
+	// Proto returns the protocol of the response.
+	Proto() string
+	// ConnReused returns true if the connection was reused
+	// by the second request of KeepAlive.
+	ConnReused() bool
+	// Err returns the error returned by the client, if any.
+	Err() error
+}
+
+// HTTPClientResulter provides methods to read HTTPClientRunner results
+// after a dry run.
+type HTTPClientResulter interface {
+	Resulter
+	// Requests returns the requests captured by the stub server,
+	// in the order they were received.
+	Requests() []*http.Request
+	// Err returns the error returned by the tested func.
+	Err() error
+}
+
+// CallResulter provides methods to read CallRunner results
+// after a dry run.
+type CallResulter interface {
+	Resulter
+	// Outs returns the values returned by the func,
+	// or nil if it panicked.
+	Outs() []interface{}
+	// Panic returns the value recovered from the func's panic,
+	// or nil if it did not panic.
+	Panic() interface{}
+	// Duration returns the func's execution time.
+	Duration() time.Duration
+	// Durations returns the func's execution times
+	// of the timed calls set via Repeat.
+	Durations() []time.Duration
+	// Allocs returns the average number of allocations of a call,
+	// if measured.
+	Allocs() int
+	// AllocBytes returns the average number of bytes allocated
+	// by a call, if measured.
+	AllocBytes() int
+}
+
+// PollResulter provides methods to read PollRunner results
+// after a dry run.
+type PollResulter interface {
+	Resulter
+	// Value returns the last polled value.
+	Value() interface{}
+	// Attempts returns the number of times the value was polled.
+	Attempts() int
+	// Elapsed returns the time elapsed between the first and last polls.
+	Elapsed() time.Duration
+}
+
+// ChanResulter provides methods to read ChanRunner results
+// after a dry run.
+type ChanResulter interface {
+	Resulter
+	// Received returns the values received from the channel, in order.
+	Received() []interface{}
+	// Closed returns true if the channel was found closed.
+	Closed() bool
 }
 
 // TableResulter provides methods to read TableRunner results
 // after a dry run.
 type TableResulter interface {
 	Resulter
+	// Cases returns a slice of CaseResults listing the run test cases
+	// in order, each one with its own checks results.
+	Cases() []CaseResult
+	// NSkipped returns the number of skipped test cases.
+	NSkipped() int
+	// Focused returns true if a test case set Case.Only. If so,
+	// the results include a failed check reporting it.
+	Focused() bool
 	// PassedAt returns true if the ith test case passed.
 	PassedAt(index int) bool
 	// FailedAt returns true if the ith test case failed.
 	FailedAt(index int) bool
-	// PassedLabel returns true if the test case with matching label passed.
+	// PassedLabel returns true if the test cases with matching label passed.
 	PassedLabel(label string) bool
-	// FailedLabel returns true if the test case with matching label failed.
+	// FailedLabel returns true if a test case with matching label failed.
 	FailedLabel(label string) bool
 }
 
@@ -143,6 +518,64 @@ func Value(v interface{}) ValueRunner {
 	return newValueRunner(v)
 }
 
+// Call returns a CallRunner to run tests on the call of fn with args.
+// Nil args are replaced by the zero value of the corresponding parameter.
+// fn is only called when the runner is run. It panics if fn is not a func
+// or cannot be called with args.
+//
+// 	testx.Call(strconv.Atoi, "42").
+// 		Out(0, check.Value.Is(42)).
+// 		Err(check.Value.Is(nil)).
+// 		Run(t)
+func Call(fn interface{}, args ...interface{}) CallRunner {
+	return newCallRunner(fn, args...)
+}
+
+// Eventually returns a PollRunner that polls the value returned by get
+// until it passes all checkers. It fails if it does not before the timeout,
+// in which case the checks are reported on the last polled value
+// with the number of attempts.
+//
+// 	testx.Eventually(func() interface{} { return cache.Len() },
+// 		check.Value.Is(3),
+// 	).Timeout(100 * time.Millisecond).Run(t)
+func Eventually(get func() interface{}, checkers ...check.ValueChecker) PollRunner {
+	return newPollRunner(get, checkers, false)
+}
+
+// Consistently returns a PollRunner that polls the value returned by get
+// for the whole polling window, expecting it to pass all checkers each time.
+// It stops at the first value failing a checker, which is then reported
+// with the number of attempts.
+func Consistently(get func() interface{}, checkers ...check.ValueChecker) PollRunner {
+	return newPollRunner(get, checkers, true)
+}
+
+// Chan returns a ChanRunner to run tests on the values received
+// from ch, which can be any receivable channel.
+// It panics if ch is not a non-nil receivable channel.
+//
+// 	testx.Chan(pipeline.Out()).
+// 		ReceivesInOrder(1, 2, 3).
+// 		Closes(100 * time.Millisecond).
+// 		Run(t)
+func Chan(ch interface{}) ChanRunner {
+	return newChanRunner(ch)
+}
+
+// LeakCheck fails t if goroutines started during the test are still
+// running when it ends, after a grace period of 100ms, reporting their
+// stacks. Runtime and testing goroutines are ignored.
+//
+// 	func TestWorker(t *testing.T) {
+// 		testx.LeakCheck(t)
+// 		// ...
+// 	}
+func LeakCheck(t *testing.T) {
+	t.Helper()
+	leakCheck(t)
+}
+
 // HTTPHandler returns a HandlerRunner to run tests on http handlers
 // and middlewares.
 func HTTPHandler(
@@ -167,9 +600,77 @@ func HTTPHandlerFunc(
 	)
 }
 
+// HTTPServer returns a HTTPServerRunner to run tests on h served
+// by an httptest.Server, started with the given options.
+// A nil handler is interpreted as a no-op handler.
+//
+// Example:
+// 	testx.HTTPServer(h, testx.ServerHTTP2()).
+// 		Proto(check.String.Is("HTTP/2.0")).
+// 		Run(t)
+func HTTPServer(h http.Handler, opts ...ServerOption) HTTPServerRunner {
+	return newHTTPServerRunner(httpconv.SafeHandler(h), opts...)
+}
+
+// HTTPClient returns a HTTPClientRunner to run tests on the requests
+// sent by fn. fn is called with the client and base URL of a stub
+// server started with the given options, that captures the requests
+// and writes the responses set via HTTPClientRunner.Respond.
+//
+// Example:
+// 	testx.HTTPClient(func(c *http.Client, baseURL string) error {
+// 		_, err := api.NewClient(c, baseURL).GetMovie(42)
+// 		return err
+// 	}).
+// 		Respond(testx.StubResponse{Body: []byte(`{"id":42}`)}).
+// 		Request(0, check.HTTPRequest.Path(check.String.Is("/movies/42"))).
+// 		Run(t)
+func HTTPClient(fn func(c *http.Client, baseURL string) error, opts ...ServerOption) HTTPClientRunner {
+	return newHTTPClientRunner(fn, opts...)
+}
+
+// NewRequest returns a RequestBuilder to build a request
+// with the given method and target, such as "/movies?id=42".
+//
+// Example:
+// 	rq := testx.NewRequest("POST", "/movies").
+// 		BearerAuth(token).
+// 		JSON(movie).
+// 		Build()
+// 	testx.HTTPHandlerFunc(CreateMovie).WithRequest(rq).Run(t)
+func NewRequest(method, target string) RequestBuilder {
+	return newRequestBuilder(method, target)
+}
+
 // Table returns a TableRunner to run test cases on a func. By default,
 // it works with funcs having a single input and output value.
 // Use TableRunner.Config to configure it for a more complex functions.
 func Table(testedFunc interface{}) TableRunner {
 	return newTableRunner(testedFunc)
 }
+
+// Diff returns a TableRunner to run differential tests of func f
+// against the reference func ref. It is a shortcut for
+// Table(f).Oracle(ref), so cases may only provide the inputs:
+//
+// 	testx.Diff(fastSqrt, math.Sqrt).Cases([]testx.Case{
+// 		{In: 0.}, {In: 2.}, {In: 1e9},
+// 	})
+func Diff(f, ref interface{}) TableRunner {
+	return newTableRunner(f).Oracle(ref)
+}
+
+// Method returns a TableRunner to run test cases on the method
+// of recv's type with the given name. recv is the default receiver
+// for all cases, it can be overridden per case using Case.Receiver.
+// The receiver is not a parameter in regard to TableConfig
+// and Case.Args.
+//
+// 	// test (*Cache).Get with a receiver for each case
+// 	testx.Method((*Cache)(nil), "Get").Cases([]testx.Case{
+// 		{Receiver: emptyCache, In: "k", Exp: nil},
+// 		{Receiver: filledCache, In: "k", Exp: 1},
+// 	})
+func Method(recv interface{}, name string) TableRunner {
+	return newMethodTableRunner(recv, name)
+}
@@ -1,9 +1,14 @@
 package testx
 
 import (
+	"runtime"
+	"testing"
 	"time"
 )
 
+// allocRuns is the number of runs allocations are averaged over.
+const allocRuns = 100
+
 // timeFunc executes the given func and returns the elapsed time
 // during the execution.
 func timeFunc(f func()) time.Duration {
@@ -11,3 +16,75 @@ func timeFunc(f func()) time.Duration {
 	f()
 	return time.Since(t0)
 }
+
+// allocsPerRun returns the average number of allocations and of bytes
+// allocated by f over the given number of runs, less the ones of baseline.
+// The former is measured by testing.AllocsPerRun, the latter
+// via runtime.MemStats.
+func allocsPerRun(runs int, f, baseline func()) (allocs, bytes int) {
+	allocs = int(testing.AllocsPerRun(runs, f) - testing.AllocsPerRun(runs, baseline))
+	bytes = int(bytesPerRun(runs, f)) - int(bytesPerRun(runs, baseline))
+	return max0(allocs), max0(bytes)
+}
+
+// bytesPerRun returns the average number of bytes allocated by f
+// over the given number of runs, after a warm-up run. Like
+// testing.AllocsPerRun, it sets GOMAXPROCS to 1 during the measure.
+func bytesPerRun(runs int, f func()) uint64 {
+	defer runtime.GOMAXPROCS(runtime.GOMAXPROCS(1))
+	f()
+	var memstats runtime.MemStats
+	runtime.ReadMemStats(&memstats)
+	total := memstats.TotalAlloc
+	for i := 0; i < runs; i++ {
+		f()
+	}
+	runtime.ReadMemStats(&memstats)
+	return (memstats.TotalAlloc - total) / uint64(runs)
+}
+
+// sampler collects the durations of repeated runs of a func,
+// after untimed warm-up runs.
+type sampler struct {
+	repeat, warmup int
+}
+
+func (s *sampler) setRepeat(n int) {
+	if n < 1 {
+		panic(errSamplingCountMin("Repeat", n, 1))
+	}
+	s.repeat = n
+}
+
+func (s *sampler) setWarmup(k int) {
+	if k < 0 {
+		panic(errSamplingCountMin("Warmup", k, 0))
+	}
+	s.warmup = k
+}
+
+// replays returns true if the sampled func is run more than once.
+func (s sampler) replays() bool {
+	return s.repeat > 1 || s.warmup > 0
+}
+
+// run calls next for each warm-up run, then first and next
+// for the timed runs, and returns the durations of the latter.
+func (s sampler) run(first, next func()) []time.Duration {
+	for i := 0; i < s.warmup; i++ {
+		next()
+	}
+	samples := make([]time.Duration, 0, s.repeat)
+	samples = append(samples, timeFunc(first))
+	for len(samples) < s.repeat {
+		samples = append(samples, timeFunc(next))
+	}
+	return samples
+}
+
+func max0(n int) int {
+	if n < 0 {
+		return 0
+	}
+	return n
+}